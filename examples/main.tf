@@ -22,29 +22,13 @@ provider "redmine" {
 }
 */
 
-locals {
-  redmine_default_issue_tracker_ids = {
-    bug = 1
-    user_story = 2
-    support = 3
-  }
-
-  redmine_default_issue_priorities_ids = {
-    low = 1
-    normal = 2
-    high = 3
-    urgent = 4
-    immediate = 5
-  }
+// trackers and priorities are looked up by name because their IDs may differ between Redmine instances
+data "redmine_tracker" "feature" {
+  name = "Feature"
+}
 
-  redmine_default_issue_status_ids = {
-    new = 1
-    in_progress = 2
-    resolved = 3
-    feedback = 4
-    closed = 5
-    rejected = 6
-  }
+data "redmine_issue_priority" "immediate" {
+  name = "Immediate"
 }
 
 resource "redmine_project" "project1" {
@@ -58,7 +42,7 @@ resource "redmine_project" "project1" {
 
 resource "redmine_issue" "issue1" {
   project_id = redmine_project.project1.id
-  tracker_id = local.redmine_default_issue_tracker_ids.user_story
+  tracker_id = data.redmine_tracker.feature.id
   subject = "Something should be done"
   description = <<EOT
 An **important task** _should_ *be done*!
@@ -70,7 +54,7 @@ codeblock() {
 ```
 EOT
   // the priority cannot be deleted but it can be replaced. If not provided here, Redmine takes the default or previously used priority
-  priority_id = local.redmine_default_issue_priorities_ids.immediate
+  priority_id = data.redmine_issue_priority.immediate.id
   // the category can be added, replaced, and be deleted
   category_id = redmine_issue_category.issue_category_dev.id
 }
@@ -4,6 +4,7 @@ go 1.14
 
 require (
 	github.com/cloudogu/go-redmine v0.2.0
+	github.com/hashicorp/go-cty v1.4.1-0.20200414143053-d3edf31b6320
 	github.com/hashicorp/terraform-plugin-docs v0.4.0 // indirect
 	github.com/hashicorp/terraform-plugin-sdk/v2 v2.4.1
 	github.com/pkg/errors v0.9.1
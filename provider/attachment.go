@@ -0,0 +1,344 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/cloudogu/terraform-provider-redmine/redmine"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"strings"
+)
+
+const (
+	AttFilename    = "filename"
+	AttSource      = "source"
+	AttContent     = "content"
+	AttDescription = "description"
+	AttContentType = "content_type"
+	AttContentHash = "content_hash"
+)
+
+// AttachmentClient provides methods for uploading files and modifying the attachments of issues and wiki pages.
+type AttachmentClient interface {
+	// UploadFile uploads the content of a file and returns an upload which must be sent with an issue or a wiki page.
+	UploadFile(ctx context.Context, filename string, content io.Reader, size int64) (*redmine.Upload, error)
+	// UpdateAttachment changes the description of an attachment.
+	UpdateAttachment(ctx context.Context, attachment *redmine.Attachment) error
+	// DeleteAttachment deletes an attachment identified by the id.
+	DeleteAttachment(ctx context.Context, id int) error
+}
+
+// attachmentSchema returns the schema of the attachment blocks. The content is either read from the local file source
+// or given inline as content. content_hash contains the SHA256 checksum of the uploaded content; files are only
+// uploaded again if the checksum changes.
+func attachmentSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				AttFilename: {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				AttSource: {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				AttContent: {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				AttDescription: {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				// Redmine guesses the content type from the filename if it is not configured
+				AttContentType: {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				AttContentHash: {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+// attachment contains a configured attachment together with the checksum of its content.
+type attachment struct {
+	Filename    string
+	Source      string
+	Content     string
+	Description string
+	ContentType string
+	ContentHash string
+}
+
+var sha256Digest = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// customizeAttachmentDiff validates the attachments under the given key and plans an update of the given computed key
+// if the content of an attachment changed while its configuration did not, f. i. because the source file was edited.
+func customizeAttachmentDiff(key, updatedKey string) schema.CustomizeDiffFunc {
+	return func(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+		rawAttachments := d.Get(key).([]interface{})
+
+		filenames := map[string]bool{}
+		contentUnknown := false
+		for i, rawAttachment := range rawAttachments {
+			configured := attachmentFromMap(rawAttachment.(map[string]interface{}))
+			if filenames[configured.Filename] {
+				return fmt.Errorf("%s filenames must be unique but %s is used more than once", key, configured.Filename)
+			}
+			filenames[configured.Filename] = true
+
+			if !d.NewValueKnown(fmt.Sprintf("%s.%d.%s", key, i, AttSource)) ||
+				!d.NewValueKnown(fmt.Sprintf("%s.%d.%s", key, i, AttContent)) {
+				contentUnknown = true
+				continue
+			}
+			if (configured.Source == "") == (configured.Content == "") {
+				return fmt.Errorf("%s %s must contain either %s or %s", key, configured.Filename, AttSource, AttContent)
+			}
+		}
+
+		if d.Id() == "" {
+			return nil
+		}
+		if contentUnknown {
+			return d.SetNewComputed(updatedKey)
+		}
+
+		oldAttachments, _ := d.GetChange(key)
+		for _, configured := range attachmentsFromList(rawAttachments) {
+			previous := findAttachment(attachmentsFromList(oldAttachments.([]interface{})), configured.Filename)
+			if previous == nil {
+				continue
+			}
+
+			contentHash, err := attachmentContentHash(configured)
+			if err != nil {
+				return err
+			}
+			if contentHash != previous.ContentHash {
+				return d.SetNewComputed(updatedKey)
+			}
+		}
+
+		return nil
+	}
+}
+
+// attachmentContentHash returns the SHA256 checksum of the attachment's content.
+func attachmentContentHash(att *attachment) (string, error) {
+	content, _, err := openAttachment(att)
+	if err != nil {
+		return "", err
+	}
+	defer content.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, content); err != nil {
+		return "", errors.Wrapf(err, "could not read attachment %s", att.Filename)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// openAttachment opens the attachment's content and returns its size in bytes.
+func openAttachment(att *attachment) (io.ReadCloser, int64, error) {
+	if att.Source == "" {
+		return ioutil.NopCloser(strings.NewReader(att.Content)), int64(len(att.Content)), nil
+	}
+
+	file, err := os.Open(att.Source)
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, "could not read attachment %s", att.Filename)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return nil, 0, errors.Wrapf(err, "could not read attachment %s", att.Filename)
+	}
+
+	return file, info.Size(), nil
+}
+
+// uploadAttachments uploads the content of the given attachments and sets their content hashes. The content hash is
+// calculated while uploading so that each file is read only once.
+func uploadAttachments(ctx context.Context, client AttachmentClient, attachments []*attachment) ([]redmine.Upload, error) {
+	var uploads []redmine.Upload
+
+	for _, att := range attachments {
+		content, size, err := openAttachment(att)
+		if err != nil {
+			return nil, err
+		}
+		hash := sha256.New()
+		upload, err := client.UploadFile(ctx, att.Filename, io.TeeReader(content, hash), size)
+		_ = content.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		upload.Description = att.Description
+		upload.ContentType = att.ContentType
+		uploads = append(uploads, *upload)
+		att.ContentHash = hex.EncodeToString(hash.Sum(nil))
+	}
+
+	return uploads, nil
+}
+
+// syncAttachments deletes or updates the existing attachments so that they match the configured attachments and
+// returns those configured attachments that must be uploaded because they are new or their content changed. Existing
+// attachments are identified by their filename.
+func syncAttachments(ctx context.Context, client AttachmentClient, configured, previous []*attachment,
+	existing []redmine.Attachment) ([]*attachment, error) {
+	var toUpload []*attachment
+
+	for _, prev := range previous {
+		if findAttachment(configured, prev.Filename) != nil {
+			continue
+		}
+		if err := deleteAttachments(ctx, client, existing, prev.Filename); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, att := range configured {
+		prev := findAttachment(previous, att.Filename)
+		if prev == nil {
+			toUpload = append(toUpload, att)
+			continue
+		}
+
+		contentHash, err := attachmentContentHash(att)
+		if err != nil {
+			return nil, err
+		}
+		if contentHash != prev.ContentHash || att.ContentType != prev.ContentType {
+			if err := deleteAttachments(ctx, client, existing, att.Filename); err != nil {
+				return nil, err
+			}
+			toUpload = append(toUpload, att)
+			continue
+		}
+
+		att.ContentHash = contentHash
+		if att.Description != prev.Description {
+			for _, existingAtt := range existing {
+				if existingAtt.Filename == att.Filename {
+					existingAtt.Description = att.Description
+					if err := client.UpdateAttachment(ctx, &existingAtt); err != nil {
+						return nil, err
+					}
+				}
+			}
+		}
+	}
+
+	return toUpload, nil
+}
+
+func deleteAttachments(ctx context.Context, client AttachmentClient, existing []redmine.Attachment, filename string) error {
+	for _, existingAtt := range existing {
+		if existingAtt.Filename != filename {
+			continue
+		}
+		if err := client.DeleteAttachment(ctx, existingAtt.ID); err != nil && !redmine.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// attachmentsSetToState sets the given attachments but drops those that were deleted in Redmine. The content hash is
+// taken from Redmine if Redmine calculated a SHA256 checksum, so that files replaced outside of Terraform are
+// uploaded again.
+func attachmentsSetToState(attachments []*attachment, existing []redmine.Attachment, key string, d *schema.ResourceData) error {
+	stateAttachments := []interface{}{}
+
+	for _, att := range attachments {
+		var found *redmine.Attachment
+		for i := range existing {
+			if existing[i].Filename == att.Filename {
+				found = &existing[i]
+			}
+		}
+		if found == nil {
+			continue
+		}
+
+		contentHash := att.ContentHash
+		if sha256Digest.MatchString(found.Digest) {
+			contentHash = found.Digest
+		}
+
+		stateAttachment := attachmentToMap(att)
+		stateAttachment[AttContentHash] = contentHash
+		stateAttachments = append(stateAttachments, stateAttachment)
+	}
+
+	return d.Set(key, stateAttachments)
+}
+
+func attachmentsToList(attachments []*attachment) []interface{} {
+	rawAttachments := []interface{}{}
+	for _, att := range attachments {
+		rawAttachments = append(rawAttachments, attachmentToMap(att))
+	}
+
+	return rawAttachments
+}
+
+func attachmentToMap(att *attachment) map[string]interface{} {
+	return map[string]interface{}{
+		AttFilename:    att.Filename,
+		AttSource:      att.Source,
+		AttContent:     att.Content,
+		AttDescription: att.Description,
+		AttContentType: att.ContentType,
+		AttContentHash: att.ContentHash,
+	}
+}
+
+func attachmentsFromList(rawAttachments []interface{}) []*attachment {
+	var attachments []*attachment
+	for _, rawAttachment := range rawAttachments {
+		attachments = append(attachments, attachmentFromMap(rawAttachment.(map[string]interface{})))
+	}
+
+	return attachments
+}
+
+func attachmentFromMap(attachmentMap map[string]interface{}) *attachment {
+	att := &attachment{}
+	att.Filename, _ = attachmentMap[AttFilename].(string)
+	att.Source, _ = attachmentMap[AttSource].(string)
+	att.Content, _ = attachmentMap[AttContent].(string)
+	att.Description, _ = attachmentMap[AttDescription].(string)
+	att.ContentType, _ = attachmentMap[AttContentType].(string)
+	att.ContentHash, _ = attachmentMap[AttContentHash].(string)
+
+	return att
+}
+
+func findAttachment(attachments []*attachment, filename string) *attachment {
+	for _, att := range attachments {
+		if att.Filename == filename {
+			return att
+		}
+	}
+
+	return nil
+}
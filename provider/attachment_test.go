@@ -0,0 +1,114 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/cloudogu/terraform-provider-redmine/redmine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+// sha256 of "restart the service"
+const testAttachmentHash = "616379a82174fec9fd0fbc534b74004c0e3d31e61a3d021b028b94529fb21ca1"
+
+func Test_attachmentContentHash(t *testing.T) {
+	file, err := ioutil.TempFile("", "runbook*.txt")
+	require.NoError(t, err)
+	defer os.Remove(file.Name())
+	_, err = file.WriteString("restart the service")
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+	source := file.Name()
+
+	fromFile, err := attachmentContentHash(&attachment{Filename: "runbook.txt", Source: source})
+	require.NoError(t, err)
+	inline, err := attachmentContentHash(&attachment{Filename: "runbook.txt", Content: "restart the service"})
+	require.NoError(t, err)
+
+	assert.Equal(t, testAttachmentHash, fromFile)
+	assert.Equal(t, testAttachmentHash, inline)
+}
+
+func Test_uploadAttachments(t *testing.T) {
+	file, err := ioutil.TempFile("", "runbook*.txt")
+	require.NoError(t, err)
+	defer os.Remove(file.Name())
+	_, err = file.WriteString("restart the service")
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+	attachments := []*attachment{
+		{Filename: "runbook.txt", Source: file.Name(), Description: "how to restart"},
+		{Filename: "inline.txt", Content: "restart the service"},
+	}
+
+	uploads, err := uploadAttachments(context.Background(), &fakeAttachmentClient{}, attachments)
+
+	require.NoError(t, err)
+	assert.Equal(t, []redmine.Upload{
+		{Token: "token-runbook.txt", Filename: "runbook.txt", Description: "how to restart"},
+		{Token: "token-inline.txt", Filename: "inline.txt"},
+	}, uploads)
+	assert.Equal(t, testAttachmentHash, attachments[0].ContentHash)
+	assert.Equal(t, testAttachmentHash, attachments[1].ContentHash)
+}
+
+func Test_syncAttachments(t *testing.T) {
+	existing := []redmine.Attachment{
+		{ID: 1, Filename: "unchanged.txt"},
+		{ID: 2, Filename: "described.txt"},
+		{ID: 3, Filename: "changed.txt"},
+		{ID: 4, Filename: "removed.txt"},
+	}
+	previous := []*attachment{
+		{Filename: "unchanged.txt", Content: "restart the service", ContentHash: testAttachmentHash},
+		{Filename: "described.txt", Content: "restart the service", ContentHash: testAttachmentHash},
+		{Filename: "changed.txt", Content: "restart the service", ContentHash: testAttachmentHash},
+		{Filename: "removed.txt", Content: "restart the service", ContentHash: testAttachmentHash},
+	}
+	configured := []*attachment{
+		{Filename: "unchanged.txt", Content: "restart the service"},
+		{Filename: "described.txt", Content: "restart the service", Description: "how to restart"},
+		{Filename: "changed.txt", Content: "restart the service twice"},
+		{Filename: "new.txt", Content: "stop the service"},
+	}
+	client := &fakeAttachmentClient{}
+
+	toUpload, err := syncAttachments(context.Background(), client, configured, previous, existing)
+
+	require.NoError(t, err)
+	assert.Equal(t, []*attachment{configured[2], configured[3]}, toUpload)
+	assert.Equal(t, []int{4, 3}, client.deletedIDs)
+	assert.Equal(t, []redmine.Attachment{{ID: 2, Filename: "described.txt", Description: "how to restart"}}, client.updated)
+	assert.Equal(t, testAttachmentHash, configured[0].ContentHash)
+}
+
+type fakeAttachmentClient struct {
+	deletedIDs []int
+	updated    []redmine.Attachment
+}
+
+func (f *fakeAttachmentClient) UploadFile(_ context.Context, filename string, content io.Reader, size int64) (*redmine.Upload, error) {
+	uploaded, err := ioutil.ReadAll(content)
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(uploaded)) != size {
+		return nil, fmt.Errorf("uploaded %d bytes of %s but expected %d", len(uploaded), filename, size)
+	}
+
+	return &redmine.Upload{Token: "token-" + filename, Filename: filename}, nil
+}
+
+func (f *fakeAttachmentClient) UpdateAttachment(_ context.Context, attachment *redmine.Attachment) error {
+	f.updated = append(f.updated, *attachment)
+	return nil
+}
+
+func (f *fakeAttachmentClient) DeleteAttachment(_ context.Context, id int) error {
+	f.deletedIDs = append(f.deletedIDs, id)
+	return nil
+}
@@ -0,0 +1,147 @@
+package provider
+
+import (
+	"github.com/cloudogu/terraform-provider-redmine/redmine"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	CfID     = "id"
+	CfValue  = "value"
+	CfValues = "values"
+)
+
+// customFieldSchema returns the schema of the custom_field blocks which resources use to manage custom field values.
+// Single-valued custom fields are configured with value, multi-valued custom fields with values.
+//
+// Only custom fields that are declared in the configuration are managed. Other custom fields of the entity are ignored
+// so that custom fields added by Redmine administrators do not lead to a diff.
+func customFieldSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				CfID: {
+					Type:     schema.TypeInt,
+					Required: true,
+				},
+				CfValue: {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				CfValues: {
+					Type:     schema.TypeSet,
+					Optional: true,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+			},
+		},
+	}
+}
+
+// computedCustomFieldSchema returns the schema of the custom_field blocks which data sources use to expose the custom
+// field values of an entity.
+func computedCustomFieldSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeSet,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				CfID: {
+					Type:     schema.TypeInt,
+					Computed: true,
+				},
+				CfValue: {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				CfValues: {
+					Type:     schema.TypeSet,
+					Computed: true,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+			},
+		},
+	}
+}
+
+func customFieldsFromState(d *schema.ResourceData, key string) []redmine.CustomField {
+	var customFields []redmine.CustomField
+
+	for _, rawCustomField := range d.Get(key).(*schema.Set).List() {
+		customFieldMap := rawCustomField.(map[string]interface{})
+		customField := redmine.CustomField{ID: customFieldMap[CfID].(int)}
+
+		values := customFieldMap[CfValues].(*schema.Set).List()
+		if len(values) > 0 {
+			customField.Multiple = true
+			for _, value := range values {
+				customField.Values = append(customField.Values, value.(string))
+			}
+		} else if value := customFieldMap[CfValue].(string); value != "" {
+			customField.Values = []string{value}
+		}
+
+		customFields = append(customFields, customField)
+	}
+
+	return customFields
+}
+
+// customFieldsSetToState sets the values of those custom fields that are already declared under the given key. All
+// other custom fields are ignored.
+func customFieldsSetToState(customFields []redmine.CustomField, key string, d *schema.ResourceData) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	declaredIDs := map[int]bool{}
+	for _, rawCustomField := range d.Get(key).(*schema.Set).List() {
+		declaredIDs[rawCustomField.(map[string]interface{})[CfID].(int)] = true
+	}
+
+	var stateCustomFields []interface{}
+	for _, customField := range customFields {
+		if declaredIDs[customField.ID] {
+			stateCustomFields = append(stateCustomFields, customFieldToState(customField))
+		}
+	}
+
+	if err := d.Set(key, stateCustomFields); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+// allCustomFieldsSetToState sets the values of all given custom fields. Data sources use it to expose every custom
+// field of the entity.
+func allCustomFieldsSetToState(customFields []redmine.CustomField, key string, d *schema.ResourceData) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var stateCustomFields []interface{}
+	for _, customField := range customFields {
+		stateCustomFields = append(stateCustomFields, customFieldToState(customField))
+	}
+
+	if err := d.Set(key, stateCustomFields); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func customFieldToState(customField redmine.CustomField) map[string]interface{} {
+	stateCustomField := map[string]interface{}{CfID: customField.ID}
+	if customField.Multiple {
+		values := make([]interface{}, 0, len(customField.Values))
+		for _, value := range customField.Values {
+			values = append(values, value)
+		}
+		stateCustomField[CfValues] = values
+	} else if len(customField.Values) > 0 {
+		stateCustomField[CfValue] = customField.Values[0]
+	}
+
+	return stateCustomField
+}
@@ -0,0 +1,35 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/cloudogu/terraform-provider-redmine/redmine"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_customFieldsSetToState(t *testing.T) {
+	t.Run("should ignore custom fields that are not declared", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, resourceIssue().Schema, map[string]interface{}{
+			IssCustomField: []interface{}{
+				map[string]interface{}{CfID: 1, CfValue: "low"},
+				map[string]interface{}{CfID: 2, CfValues: []interface{}{"linux"}},
+			},
+		})
+		customFields := []redmine.CustomField{
+			{ID: 1, Values: []string{"high"}},
+			{ID: 2, Multiple: true, Values: []string{"linux", "windows"}},
+			{ID: 3, Values: []string{"default set by Redmine"}},
+		}
+
+		diags := customFieldsSetToState(customFields, IssCustomField, d)
+
+		require.False(t, diags.HasError())
+		actual := customFieldsFromState(d, IssCustomField)
+		assert.ElementsMatch(t, []redmine.CustomField{
+			{ID: 1, Values: []string{"high"}},
+			{ID: 2, Multiple: true, Values: []string{"linux", "windows"}},
+		}, actual)
+	})
+}
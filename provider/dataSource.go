@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"fmt"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"strings"
+)
+
+// lookupNameSchema returns the schema of a name attribute which is required when a single entity is looked up by its
+// name and computed when the entity is part of a list.
+func lookupNameSchema(lookupByName bool) *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeString,
+		Required: lookupByName,
+		Computed: !lookupByName,
+	}
+}
+
+// attributesSetToState sets all attributes of an entity except its ID which must be set with SetId.
+func attributesSetToState(attributes map[string]interface{}, d *schema.ResourceData) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for key, value := range attributes {
+		if key == "id" {
+			continue
+		}
+		if err := d.Set(key, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return diags
+}
+
+// notFoundByName returns a diagnostic which lists the available names so that typos in the configuration are easy to
+// spot, f. i. when a name differs between Redmine instances.
+func notFoundByName(entityName, nameAttribute, name string, availableNames []string) diag.Diagnostics {
+	return diag.Diagnostics{{
+		Severity:      diag.Error,
+		Summary:       fmt.Sprintf("%s '%s' was not found", entityName, name),
+		Detail:        fmt.Sprintf("available names: %s", strings.Join(availableNames, ", ")),
+		AttributePath: cty.GetAttrPath(nameAttribute),
+	}}
+}
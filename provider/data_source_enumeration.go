@@ -0,0 +1,138 @@
+package provider
+
+import (
+	"context"
+	"github.com/cloudogu/terraform-provider-redmine/redmine"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"log"
+)
+
+const (
+	EnumID        = "id"
+	EnumName      = "name"
+	EnumIsDefault = "is_default"
+	EnumActive    = "active"
+
+	EnumIssuePriorities     = "issue_priorities"
+	EnumTimeEntryActivities = "time_entry_activities"
+)
+
+// EnumerationClient provides methods for reading the values of Redmine's enumerations.
+type EnumerationClient interface {
+	// ReadIssuePriorities reads all issue priorities.
+	ReadIssuePriorities(ctx context.Context) ([]*redmine.Enumeration, error)
+	// ReadTimeEntryActivities reads all time entry activities.
+	ReadTimeEntryActivities(ctx context.Context) ([]*redmine.Enumeration, error)
+}
+
+// enumerationReader reads all values of one enumeration. EnumerationClient's methods can be used as method expressions,
+// f. i. EnumerationClient.ReadIssuePriorities.
+type enumerationReader func(client EnumerationClient, ctx context.Context) ([]*redmine.Enumeration, error)
+
+// dataSourceIssuePriority looks up a single issue priority by its name.
+func dataSourceIssuePriority() *schema.Resource {
+	return dataSourceEnumerationValue("issue priority", EnumerationClient.ReadIssuePriorities)
+}
+
+// dataSourceIssuePriorities lists all issue priorities.
+func dataSourceIssuePriorities() *schema.Resource {
+	return dataSourceEnumerationValues(EnumIssuePriorities, EnumerationClient.ReadIssuePriorities)
+}
+
+// dataSourceTimeEntryActivity looks up a single time entry activity by its name.
+func dataSourceTimeEntryActivity() *schema.Resource {
+	return dataSourceEnumerationValue("time entry activity", EnumerationClient.ReadTimeEntryActivities)
+}
+
+// dataSourceTimeEntryActivities lists all time entry activities.
+func dataSourceTimeEntryActivities() *schema.Resource {
+	return dataSourceEnumerationValues(EnumTimeEntryActivities, EnumerationClient.ReadTimeEntryActivities)
+}
+
+func enumerationSchema(lookupByName bool) map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		EnumID: {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		EnumName: lookupNameSchema(lookupByName),
+		EnumIsDefault: {
+			Type:     schema.TypeBool,
+			Computed: true,
+		},
+		EnumActive: {
+			Type:     schema.TypeBool,
+			Computed: true,
+		},
+	}
+}
+
+func dataSourceEnumerationValue(entityName string, readEnumeration enumerationReader) *schema.Resource {
+	return &schema.Resource{
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
+			name := d.Get(EnumName).(string)
+
+			values, err := readEnumeration(i.(EnumerationClient), ctx)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+
+			var names []string
+			for _, value := range values {
+				if value.Name == name {
+					log.Printf("%s read id %s, name %s", entityName, value.ID, value.Name)
+					d.SetId(value.ID)
+					return attributesSetToState(enumerationToMap(value), d)
+				}
+				names = append(names, value.Name)
+			}
+
+			return notFoundByName(entityName, EnumName, name, names)
+		},
+		Schema: enumerationSchema(true),
+	}
+}
+
+func dataSourceEnumerationValues(listKey string, readEnumeration enumerationReader) *schema.Resource {
+	return &schema.Resource{
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
+			var diags diag.Diagnostics
+
+			values, err := readEnumeration(i.(EnumerationClient), ctx)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+
+			valueMaps := make([]interface{}, 0, len(values))
+			for _, value := range values {
+				valueMaps = append(valueMaps, enumerationToMap(value))
+			}
+
+			log.Printf("%s read count %d", listKey, len(values))
+
+			d.SetId(listKey)
+			if err := d.Set(listKey, valueMaps); err != nil {
+				return diag.FromErr(err)
+			}
+
+			return diags
+		},
+		Schema: map[string]*schema.Schema{
+			listKey: {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Resource{Schema: enumerationSchema(false)},
+			},
+		},
+	}
+}
+
+func enumerationToMap(value *redmine.Enumeration) map[string]interface{} {
+	return map[string]interface{}{
+		EnumID:        value.ID,
+		EnumName:      value.Name,
+		EnumIsDefault: value.IsDefault,
+		EnumActive:    value.Active,
+	}
+}
@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"testing"
+)
+
+const (
+	enumKeyID        = "id"
+	enumKeyIsDefault = "is_default"
+	enumKeyActive    = "active"
+)
+
+func TestAccIssuePriorityDataSource_lookupByName(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "redmine_issue_priority" "normal" {
+  name = "Normal"
+}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					// the issue priority is part of Redmine's default configuration
+					resource.TestCheckResourceAttr("data.redmine_issue_priority.normal", enumKeyID, "2"),
+					resource.TestCheckResourceAttr("data.redmine_issue_priority.normal", enumKeyIsDefault, "true"),
+					resource.TestCheckResourceAttr("data.redmine_issue_priority.normal", enumKeyActive, "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccTimeEntryActivitiesDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "redmine_time_entry_activities" "all" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.redmine_time_entry_activities.all", "time_entry_activities.#", "2"),
+					resource.TestCheckResourceAttr("data.redmine_time_entry_activities.all", "time_entry_activities.0.name", "Design"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"context"
+	"github.com/cloudogu/terraform-provider-redmine/redmine"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"log"
+)
+
+const (
+	IssStatID            = "id"
+	IssStatName          = "name"
+	IssStatIsClosed      = "is_closed"
+	IssStatIssueStatuses = "issue_statuses"
+)
+
+// IssueStatusClient provides methods for reading Redmine issue statuses.
+type IssueStatusClient interface {
+	// ReadIssueStatuses reads all issue statuses.
+	ReadIssueStatuses(ctx context.Context) ([]*redmine.IssueStatus, error)
+}
+
+// dataSourceIssueStatus looks up a single issue status by its name.
+func dataSourceIssueStatus() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceIssueStatusRead,
+		Schema:      issueStatusSchema(true),
+	}
+}
+
+// dataSourceIssueStatuses lists all issue statuses.
+func dataSourceIssueStatuses() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceIssueStatusesRead,
+		Schema: map[string]*schema.Schema{
+			IssStatIssueStatuses: {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Resource{Schema: issueStatusSchema(false)},
+			},
+		},
+	}
+}
+
+func issueStatusSchema(lookupByName bool) map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		IssStatID: {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		IssStatName: lookupNameSchema(lookupByName),
+		IssStatIsClosed: {
+			Type:     schema.TypeBool,
+			Computed: true,
+		},
+	}
+}
+
+func dataSourceIssueStatusRead(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
+	name := d.Get(IssStatName).(string)
+
+	client := i.(IssueStatusClient)
+	statuses, err := client.ReadIssueStatuses(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var names []string
+	for _, status := range statuses {
+		if status.Name == name {
+			log.Printf("issue status read id %s, name %s", status.ID, status.Name)
+			d.SetId(status.ID)
+			return attributesSetToState(issueStatusToMap(status), d)
+		}
+		names = append(names, status.Name)
+	}
+
+	return notFoundByName("issue status", IssStatName, name, names)
+}
+
+func dataSourceIssueStatusesRead(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	client := i.(IssueStatusClient)
+	statuses, err := client.ReadIssueStatuses(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	statusMaps := make([]interface{}, 0, len(statuses))
+	for _, status := range statuses {
+		statusMaps = append(statusMaps, issueStatusToMap(status))
+	}
+
+	log.Printf("issue statuses read count %d", len(statuses))
+
+	d.SetId(IssStatIssueStatuses)
+	if err := d.Set(IssStatIssueStatuses, statusMaps); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func issueStatusToMap(status *redmine.IssueStatus) map[string]interface{} {
+	return map[string]interface{}{
+		IssStatID:       status.ID,
+		IssStatName:     status.Name,
+		IssStatIsClosed: status.IsClosed,
+	}
+}
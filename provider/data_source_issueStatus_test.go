@@ -0,0 +1,44 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"testing"
+)
+
+const (
+	issStatKeyID       = "id"
+	issStatKeyIsClosed = "is_closed"
+)
+
+func TestAccIssueStatusDataSource_lookupByName(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "redmine_issue_status" "closed" {
+  name = "Closed"
+}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					// the issue status is part of Redmine's default configuration
+					resource.TestCheckResourceAttr("data.redmine_issue_status.closed", issStatKeyID, "5"),
+					resource.TestCheckResourceAttr("data.redmine_issue_status.closed", issStatKeyIsClosed, "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIssueStatusesDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "redmine_issue_statuses" "all" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.redmine_issue_statuses.all", "issue_statuses.#", "6"),
+					resource.TestCheckResourceAttr("data.redmine_issue_statuses.all", "issue_statuses.0.name", "New"),
+				),
+			},
+		},
+	})
+}
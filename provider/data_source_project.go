@@ -0,0 +1,166 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/cloudogu/terraform-provider-redmine/redmine"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"log"
+)
+
+// ProjectLookupClient provides methods for looking up Redmine projects.
+type ProjectLookupClient interface {
+	// ReadProject reads a project identified by the id. The id must not be empty string or "0".
+	ReadProject(ctx context.Context, id string) (*redmine.Project, error)
+	// ReadProjectByIdentifier reads a project identified by its identifier. The identifier must not be empty.
+	ReadProjectByIdentifier(ctx context.Context, identifier string) (*redmine.Project, error)
+	// ReadProjects reads all projects that are visible to the authenticated user.
+	ReadProjects(ctx context.Context) ([]*redmine.Project, error)
+}
+
+// dataSourceProject looks up a single project by either its numeric ID, its identifier or its exact name.
+func dataSourceProject() *schema.Resource {
+	lookupAttributes := []string{PrjID, PrjIdentifier, PrjName}
+
+	return &schema.Resource{
+		ReadContext: dataSourceProjectRead,
+		Schema: map[string]*schema.Schema{
+			PrjID: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: lookupAttributes,
+			},
+			PrjIdentifier: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: lookupAttributes,
+			},
+			PrjName: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: lookupAttributes,
+			},
+			PrjDescription: {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			PrjHomepage: {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			PrjIsPublic: {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			PrjParentID: {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			PrjInheritMembers: {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			PrjCreatedOn: {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			PrjUpdatedOn: {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			PrjEnabledModuleNames: {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			PrjTrackerIDs: {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+			PrjIssueCustomFieldIDs: {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+			PrjCustomField: computedCustomFieldSchema(),
+		},
+	}
+}
+
+func dataSourceProjectRead(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
+	client := i.(ProjectLookupClient)
+
+	var project *redmine.Project
+	var err error
+	if id, ok := d.GetOk(PrjID); ok {
+		project, err = client.ReadProject(ctx, id.(string))
+	} else if identifier, ok := d.GetOk(PrjIdentifier); ok {
+		project, err = client.ReadProjectByIdentifier(ctx, identifier.(string))
+	} else {
+		var diags diag.Diagnostics
+		project, diags = lookupProjectByName(ctx, client, d.Get(PrjName).(string))
+		if diags.HasError() {
+			return diags
+		}
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("project read id %s, identifier %s", project.ID, project.Identifier)
+
+	diags := projectSetToState(project, d)
+	if diags.HasError() {
+		return diags
+	}
+	diags = append(diags, allCustomFieldsSetToState(project.CustomFields, PrjCustomField, d)...)
+
+	return diags
+}
+
+// lookupProjectByName finds the single project with exactly the given name. Project names are not unique in Redmine,
+// so ambiguous names are reported as an error.
+func lookupProjectByName(ctx context.Context, client ProjectLookupClient, name string) (*redmine.Project, diag.Diagnostics) {
+	projects, err := client.ReadProjects(ctx)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	var names []string
+	var matches []*redmine.Project
+	for _, project := range projects {
+		if project.Name == name {
+			matches = append(matches, project)
+		}
+		names = append(names, project.Name)
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, notFoundByName("project", PrjName, name, names)
+	case 1:
+		// the project list does not contain enabled modules, trackers and issue custom fields
+		project, err := client.ReadProject(ctx, matches[0].ID)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		return project, nil
+	default:
+		var identifiers []string
+		for _, match := range matches {
+			identifiers = append(identifiers, match.Identifier)
+		}
+		return nil, diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("project name '%s' is ambiguous", name),
+			Detail:        fmt.Sprintf("look up the project by one of the identifiers %v instead", identifiers),
+			AttributePath: cty.GetAttrPath(PrjName),
+		}}
+	}
+}
@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"regexp"
+	"testing"
+)
+
+const testProjectTFDataSource = "data." + testProjectTFResourceType + ".lookup"
+
+func TestAccProjectDataSource_lookup(t *testing.T) {
+	tfProjectBlock := basicProjectWithDescription(prjValueIdentifier, prjValueName, "This is an example project") + "\n"
+
+	for _, lookupAttribute := range []string{prjKeyID, prjKeyIdentifier, prjKeyName} {
+		t.Run("by "+lookupAttribute, func(t *testing.T) {
+			resource.Test(t, resource.TestCase{
+				ProviderFactories: testAccProviders,
+				CheckDestroy:      testAccCheckProjectDestroy,
+				Steps: []resource.TestStep{
+					{
+						Config: tfProjectBlock + projectDataSourceAsHCL(lookupAttribute, testProjectTFResource+"."+lookupAttribute),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttrPair(testProjectTFDataSource, prjKeyID, testProjectTFResource, prjKeyID),
+							resource.TestCheckResourceAttr(testProjectTFDataSource, prjKeyIdentifier, prjValueIdentifier),
+							resource.TestCheckResourceAttr(testProjectTFDataSource, prjKeyName, prjValueName),
+							resource.TestCheckResourceAttr(testProjectTFDataSource, prjKeyDescription, "This is an example project"),
+							resource.TestCheckResourceAttr(testProjectTFDataSource, prjKeyHomepage, prjValueHomepage),
+							resource.TestCheckResourceAttr(testProjectTFDataSource, prjKeyInheritMembers, "true"),
+							resource.TestCheckResourceAttrSet(testProjectTFDataSource, "enabled_module_names.#"),
+							resource.TestCheckResourceAttrSet(testProjectTFDataSource, "tracker_ids.#"),
+						),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestAccProjectDataSource_parent(t *testing.T) {
+	tfProjectBlocks := basicProjectWithDescription(prjValueIdentifier, prjValueName, "This is an example project") + "\n" +
+		fmt.Sprintf(`resource "%s" "child" {
+  identifier = "childproject"
+  name = "Child Project"
+  parent_id = %s.id
+}
+`, testProjectTFResourceType, testProjectTFResource)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: tfProjectBlocks + projectDataSourceAsHCL(prjKeyIdentifier, `"childproject"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair(testProjectTFDataSource, prjKeyParentID, testProjectTFResource, prjKeyID),
+				),
+			},
+		},
+	})
+}
+
+func TestAccProjectDataSource_unknownName(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      projectDataSourceAsHCL(prjKeyName, `"does not exist"`),
+				ExpectError: regexp.MustCompile("project 'does not exist' was not found"),
+			},
+		},
+	})
+}
+
+func projectDataSourceAsHCL(lookupAttribute, value string) string {
+	return fmt.Sprintf(`data "%s" "lookup" {
+  %s = %s
+}`, testProjectTFResourceType, lookupAttribute, value)
+}
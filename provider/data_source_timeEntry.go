@@ -0,0 +1,173 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/cloudogu/terraform-provider-redmine/redmine"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"log"
+)
+
+const (
+	TimEntFrom        = "from"
+	TimEntTo          = "to"
+	TimEntTimeEntries = "time_entries"
+	TimEntTotalHours  = "total_hours"
+)
+
+// TimeEntryLookupClient provides methods for looking up Redmine time entries.
+type TimeEntryLookupClient interface {
+	// ReadTimeEntries reads all time entries that match the filter.
+	ReadTimeEntries(ctx context.Context, filter redmine.TimeEntryFilter) ([]*redmine.TimeEntry, error)
+}
+
+// dataSourceTimeEntries lists the time entries that match the configured filters together with their total hours.
+func dataSourceTimeEntries() *schema.Resource {
+	dateValidation := validation.StringMatch(dueDateYYYYMMDDRegexp, "invalid date found; expected formatted date (YYYY-MM-DD)")
+
+	return &schema.Resource{
+		ReadContext: dataSourceTimeEntriesRead,
+		Schema: map[string]*schema.Schema{
+			// project_id includes the time entries of subprojects
+			TimEntProjectID: {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			TimEntUserID: {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			TimEntActivityID: {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			TimEntFrom: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: dateValidation,
+			},
+			TimEntTo: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: dateValidation,
+			},
+			TimEntTimeEntries: {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Resource{Schema: timeEntryListSchema()},
+			},
+			TimEntTotalHours: {
+				Type:     schema.TypeFloat,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func timeEntryListSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		TimEntID: {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		TimEntProjectID: {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		TimEntIssueID: {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		TimEntUserID: {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		TimEntSpentOn: {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		TimEntHours: {
+			Type:     schema.TypeFloat,
+			Computed: true,
+		},
+		TimEntActivityID: {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		TimEntComments: {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		TimEntCreatedOn: {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		TimEntUpdatedOn: {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		TimEntCustomField: computedCustomFieldSchema(),
+	}
+}
+
+func dataSourceTimeEntriesRead(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	filter := redmine.TimeEntryFilter{
+		ProjectID:  d.Get(TimEntProjectID).(int),
+		UserID:     d.Get(TimEntUserID).(int),
+		ActivityID: d.Get(TimEntActivityID).(int),
+		From:       d.Get(TimEntFrom).(string),
+		To:         d.Get(TimEntTo).(string),
+	}
+
+	client := i.(TimeEntryLookupClient)
+	timeEntries, err := client.ReadTimeEntries(ctx, filter)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	totalHours := 0.0
+	timeEntryMaps := make([]interface{}, 0, len(timeEntries))
+	for _, timeEntry := range timeEntries {
+		timeEntryMaps = append(timeEntryMaps, timeEntryToMap(timeEntry))
+		totalHours += timeEntry.Hours
+	}
+
+	log.Printf("time entries read count %d, total hours %g", len(timeEntries), totalHours)
+
+	// the filter identifies the result
+	d.SetId(fmt.Sprintf("%s/%d/%d/%d/%s/%s", TimEntTimeEntries, filter.ProjectID, filter.UserID, filter.ActivityID,
+		filter.From, filter.To))
+	if err := d.Set(TimEntTimeEntries, timeEntryMaps); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(TimEntTotalHours, totalHours); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func timeEntryToMap(timeEntry *redmine.TimeEntry) map[string]interface{} {
+	customFields := make([]interface{}, 0, len(timeEntry.CustomFields))
+	for _, customField := range timeEntry.CustomFields {
+		customFields = append(customFields, customFieldToState(customField))
+	}
+
+	return map[string]interface{}{
+		TimEntID:          timeEntry.ID,
+		TimEntProjectID:   timeEntry.ProjectID,
+		TimEntIssueID:     timeEntry.IssueID,
+		TimEntUserID:      timeEntry.UserID,
+		TimEntSpentOn:     timeEntry.SpentOn,
+		TimEntHours:       timeEntry.Hours,
+		TimEntActivityID:  timeEntry.ActivityID,
+		TimEntComments:    timeEntry.Comments,
+		TimEntCreatedOn:   timeEntry.CreatedOn,
+		TimEntUpdatedOn:   timeEntry.UpdatedOn,
+		TimEntCustomField: customFields,
+	}
+}
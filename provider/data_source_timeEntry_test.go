@@ -0,0 +1,31 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"testing"
+)
+
+func TestAccTimeEntriesDataSource_filterByProject(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckTimeEntryDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: timeEntryAsHCL(`issue_id = redmine_issue.testissue1.id`, "1.5", "on-call") + `
+
+data "redmine_time_entries" "project" {
+  project_id = redmine_time_entry.test_time_entry1.project_id
+  from = "2021-06-01"
+  to = "2021-06-30"
+}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.redmine_time_entries.project", "time_entries.#", "1"),
+					resource.TestCheckResourceAttrPair("data.redmine_time_entries.project", "time_entries.0."+timEntKeyID,
+						testTimeEntryTFResource, timEntKeyID),
+					resource.TestCheckResourceAttr("data.redmine_time_entries.project", "time_entries.0."+timEntKeyComments, "on-call"),
+					resource.TestCheckResourceAttr("data.redmine_time_entries.project", "total_hours", "1.5"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,118 @@
+package provider
+
+import (
+	"context"
+	"github.com/cloudogu/terraform-provider-redmine/redmine"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"log"
+)
+
+const (
+	TrkID              = "id"
+	TrkName            = "name"
+	TrkDescription     = "description"
+	TrkDefaultStatusID = "default_status_id"
+	TrkTrackers        = "trackers"
+)
+
+// TrackerClient provides methods for reading Redmine trackers.
+type TrackerClient interface {
+	// ReadTrackers reads all trackers.
+	ReadTrackers(ctx context.Context) ([]*redmine.Tracker, error)
+}
+
+// dataSourceTracker looks up a single tracker by its name.
+func dataSourceTracker() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceTrackerRead,
+		Schema:      trackerSchema(true),
+	}
+}
+
+// dataSourceTrackers lists all trackers.
+func dataSourceTrackers() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceTrackersRead,
+		Schema: map[string]*schema.Schema{
+			TrkTrackers: {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Resource{Schema: trackerSchema(false)},
+			},
+		},
+	}
+}
+
+func trackerSchema(lookupByName bool) map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		TrkID: {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		TrkName: lookupNameSchema(lookupByName),
+		TrkDescription: {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		TrkDefaultStatusID: {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+	}
+}
+
+func dataSourceTrackerRead(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
+	name := d.Get(TrkName).(string)
+
+	client := i.(TrackerClient)
+	trackers, err := client.ReadTrackers(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var names []string
+	for _, tracker := range trackers {
+		if tracker.Name == name {
+			log.Printf("tracker read id %s, name %s", tracker.ID, tracker.Name)
+			d.SetId(tracker.ID)
+			return attributesSetToState(trackerToMap(tracker), d)
+		}
+		names = append(names, tracker.Name)
+	}
+
+	return notFoundByName("tracker", TrkName, name, names)
+}
+
+func dataSourceTrackersRead(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	client := i.(TrackerClient)
+	trackers, err := client.ReadTrackers(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	trackerMaps := make([]interface{}, 0, len(trackers))
+	for _, tracker := range trackers {
+		trackerMaps = append(trackerMaps, trackerToMap(tracker))
+	}
+
+	log.Printf("trackers read count %d", len(trackers))
+
+	d.SetId(TrkTrackers)
+	if err := d.Set(TrkTrackers, trackerMaps); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func trackerToMap(tracker *redmine.Tracker) map[string]interface{} {
+	return map[string]interface{}{
+		TrkID:              tracker.ID,
+		TrkName:            tracker.Name,
+		TrkDescription:     tracker.Description,
+		TrkDefaultStatusID: tracker.DefaultStatusID,
+	}
+}
@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"regexp"
+	"testing"
+)
+
+const (
+	trkKeyID              = "id"
+	trkKeyName            = "name"
+	trkKeyDefaultStatusID = "default_status_id"
+)
+
+func TestAccTrackerDataSource_lookupByName(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "redmine_tracker" "bug" {
+  name = "Bug"
+}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					// the tracker is part of Redmine's default configuration
+					resource.TestCheckResourceAttr("data.redmine_tracker.bug", trkKeyID, "1"),
+					resource.TestCheckResourceAttr("data.redmine_tracker.bug", trkKeyName, "Bug"),
+					resource.TestCheckResourceAttr("data.redmine_tracker.bug", trkKeyDefaultStatusID, "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccTrackerDataSource_unknownName(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "redmine_tracker" "bug" {
+  name = "Bugs"
+}`,
+				ExpectError: regexp.MustCompile("tracker 'Bugs' was not found"),
+			},
+		},
+	})
+}
+
+func TestAccTrackersDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "redmine_trackers" "all" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.redmine_trackers.all", "trackers.#", "3"),
+					resource.TestCheckResourceAttr("data.redmine_trackers.all", "trackers.0.name", "Bug"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"github.com/cloudogu/terraform-provider-redmine/redmine"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+	"log"
+	"strings"
+)
+
+// redmineAttributeAliases maps humanized attribute names that Redmine uses in validation messages to resource
+// attributes whose names cannot be derived automatically.
+var redmineAttributeAliases = map[string]string{
+	"parent task":    IssParentIssueID,
+	"subproject of":  PrjParentID,
+	"assignee":       IssAssignedToID,
+	"target version": IssFixedVersionID,
+	"estimated time": IssEstimatedHours,
+	"% done":         IssDoneRatio,
+	"related issue":  IssRelIssueToID,
+	"date":           TimEntSpentOn,
+	"comment":        TimEntComments,
+}
+
+// handleReadError converts an error that occurred while reading an entity into diagnostics. Entities that were
+// deleted outside of Terraform are removed from the state instead so that Terraform plans to re-create them.
+func handleReadError(d *schema.ResourceData, entityName string, err error) diag.Diagnostics {
+	if redmine.IsNotFound(err) && !d.IsNewResource() {
+		log.Printf("[WARN] %s (id: %s) was not found in Redmine, removing it from state", entityName, d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	return diag.FromErr(err)
+}
+
+// errorToDiags converts an error into diagnostics. Each of Redmine's validation messages becomes a diagnostic of its
+// own which is attached to the matching attribute of the given resource schema, f. i. "Identifier has already been
+// taken" is attached to the attribute "identifier".
+func errorToDiags(err error, resourceSchema map[string]*schema.Schema) diag.Diagnostics {
+	var validationErr *redmine.ValidationError
+	if !errors.As(err, &validationErr) || len(validationErr.Messages) == 0 {
+		return diag.FromErr(err)
+	}
+
+	var diags diag.Diagnostics
+	for _, message := range validationErr.Messages {
+		diagnostic := diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  message,
+			Detail:   err.Error(),
+		}
+		if attribute := findAttributeForMessage(message, resourceSchema); attribute != "" {
+			diagnostic.AttributePath = cty.GetAttrPath(attribute)
+		}
+		diags = append(diags, diagnostic)
+	}
+
+	return diags
+}
+
+// findAttributeForMessage returns the configurable attribute whose humanized name (f. i. "due date" for "due_date" or
+// "tracker" for "tracker_id") starts the given validation message. The longest match wins. An empty string is returned
+// if no attribute matches.
+func findAttributeForMessage(message string, resourceSchema map[string]*schema.Schema) string {
+	lowerMessage := strings.ToLower(message)
+
+	for humanized, attribute := range redmineAttributeAliases {
+		if _, ok := resourceSchema[attribute]; ok && strings.HasPrefix(lowerMessage, humanized+" ") {
+			return attribute
+		}
+	}
+
+	bestMatch := ""
+	bestMatchLength := 0
+	for attribute, attributeSchema := range resourceSchema {
+		if !attributeSchema.Optional && !attributeSchema.Required {
+			continue
+		}
+
+		humanized := strings.ReplaceAll(strings.TrimSuffix(attribute, "_id"), "_", " ")
+		if strings.HasPrefix(lowerMessage, humanized+" ") && len(humanized) > bestMatchLength {
+			bestMatch = attribute
+			bestMatchLength = len(humanized)
+		}
+	}
+
+	return bestMatch
+}
@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/cloudogu/terraform-provider-redmine/redmine"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_errorToDiags(t *testing.T) {
+	t.Run("should attach validation messages to matching attributes", func(t *testing.T) {
+		validationErr := &redmine.ValidationError{APIError: redmine.APIError{
+			StatusCode: 422,
+			Method:     "POST",
+			Path:       "/projects.json",
+			Messages:   []string{"Identifier has already been taken", "Subproject of is invalid", "Something is wrong"},
+		}}
+		err := errors.Wrap(validationErr, "error while creating project (identifier: example)")
+
+		diags := errorToDiags(err, resourceProject().Schema)
+
+		require.Len(t, diags, 3)
+		assert.Equal(t, "Identifier has already been taken", diags[0].Summary)
+		assert.Equal(t, cty.GetAttrPath(PrjIdentifier), diags[0].AttributePath)
+		assert.Equal(t, cty.GetAttrPath(PrjParentID), diags[1].AttributePath)
+		assert.Nil(t, diags[2].AttributePath)
+	})
+	t.Run("should prefer the longest matching attribute", func(t *testing.T) {
+		validationErr := &redmine.ValidationError{APIError: redmine.APIError{
+			Messages: []string{"Due date must be greater than start date", "Tracker cannot be blank"},
+		}}
+
+		diags := errorToDiags(validationErr, map[string]*schema.Schema{
+			"due":        {Type: schema.TypeString, Optional: true},
+			"due_date":   {Type: schema.TypeString, Optional: true},
+			IssTrackerID: {Type: schema.TypeInt, Required: true},
+		})
+
+		require.Len(t, diags, 2)
+		assert.Equal(t, cty.GetAttrPath("due_date"), diags[0].AttributePath)
+		assert.Equal(t, cty.GetAttrPath(IssTrackerID), diags[1].AttributePath)
+	})
+	t.Run("should return other errors as single diagnostic", func(t *testing.T) {
+		diags := errorToDiags(errors.New("oh noez"), resourceProject().Schema)
+
+		require.Len(t, diags, 1)
+		assert.Equal(t, "oh noez", diags[0].Summary)
+		assert.Nil(t, diags[0].AttributePath)
+	})
+}
@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"strconv"
+	"strings"
+)
+
+// verifyNumericImportID checks that an ID given to "terraform import" is a strictly positive number.
+func verifyNumericImportID(resourceName, id string) error {
+	idInt, err := strconv.Atoi(id)
+	if err != nil || idInt <= 0 {
+		return fmt.Errorf("could not import %s: expected a numeric ID but found '%s'", resourceName, id)
+	}
+
+	return nil
+}
+
+// diagsToError converts error diagnostics into a single error because importers cannot return diagnostics.
+func diagsToError(diags diag.Diagnostics) error {
+	if !diags.HasError() {
+		return nil
+	}
+
+	var messages []string
+	for _, d := range diags {
+		if d.Severity != diag.Error {
+			continue
+		}
+		message := d.Summary
+		if d.Detail != "" {
+			message += ": " + d.Detail
+		}
+		messages = append(messages, message)
+	}
+
+	return fmt.Errorf("%s", strings.Join(messages, "; "))
+}
@@ -2,10 +2,16 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"github.com/cloudogu/terraform-provider-redmine/redmine"
+	"github.com/pkg/errors"
+	"io/ioutil"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func Provider() *schema.Provider {
@@ -19,32 +25,120 @@ func Provider() *schema.Provider {
 			"username": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("REDMINE_USERNAME", "admin"),
+				DefaultFunc: schema.EnvDefaultFunc("REDMINE_USERNAME", ""),
 			},
 			"password": {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Sensitive:   true,
-				DefaultFunc: schema.EnvDefaultFunc("REDMINE_PASSWORD", "admin"),
+				DefaultFunc: schema.EnvDefaultFunc("REDMINE_PASSWORD", ""),
 			},
 			"skip_cert_verify": {
 				Type:        schema.TypeBool,
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("REDMINE_SKIP_CERT_VERIFY", false),
 			},
+			// Redmine's certificate is verified against these CA certificates in addition to the system's ones
+			"ca_cert_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("REDMINE_CA_CERT_FILE", ""),
+			},
+			"ca_cert_pem": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("REDMINE_CA_CERT_PEM", ""),
+			},
+			// the client certificate and key for mutual TLS are either PEM encoded or paths to PEM files
+			"client_cert": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("REDMINE_CLIENT_CERT", ""),
+			},
+			"client_key": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("REDMINE_CLIENT_KEY", ""),
+			},
+			"min_tls_version": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("REDMINE_MIN_TLS_VERSION", "1.2"),
+				ValidateFunc: validation.StringInSlice([]string{"1.0", "1.1", "1.2", "1.3"}, false),
+			},
 			"api_key": {
 				Type:        schema.TypeString,
 				Optional:    true,
+				Sensitive:   true,
 				DefaultFunc: schema.EnvDefaultFunc("REDMINE_API_KEY", ""),
 			},
+			// transiently failed requests (network errors, HTTP 429, 502, 503 and 504) are sent again after an
+			// exponentially growing backoff or after the time that Redmine requests with a Retry-After header; both
+			// are capped at retry_max_backoff
+			"retry_max_attempts": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("REDMINE_RETRY_MAX_ATTEMPTS", redmine.DefaultRetryPolicy.MaxAttempts),
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"retry_min_backoff": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("REDMINE_RETRY_MIN_BACKOFF", redmine.DefaultRetryPolicy.MinBackoff.String()),
+				ValidateFunc: validateDuration,
+			},
+			"retry_max_backoff": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("REDMINE_RETRY_MAX_BACKOFF", redmine.DefaultRetryPolicy.MaxBackoff.String()),
+				ValidateFunc: validateDuration,
+			},
+			// POST requests may create entities twice if Redmine processed them but the response got lost
+			"retry_non_idempotent": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("REDMINE_RETRY_NON_IDEMPOTENT", false),
+			},
+			// the limits are shared by all resources and data sources; 0 disables them
+			"max_requests_per_second": {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("REDMINE_MAX_REQUESTS_PER_SECOND", 0.0),
+				ValidateFunc: validation.FloatAtLeast(0),
+			},
+			"max_concurrent_requests": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("REDMINE_MAX_CONCURRENT_REQUESTS", 0),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"redmine_project":        resourceProject(),
-			"redmine_issue":          resourceIssue(),
-			"redmine_issue_category": resourceIssueCategory(),
-			"redmine_version":        resourceVersion(),
+			"redmine_project":            resourceProject(),
+			"redmine_issue":              resourceIssue(),
+			"redmine_issue_category":     resourceIssueCategory(),
+			"redmine_issue_relation":     resourceIssueRelation(),
+			"redmine_time_entry":         resourceTimeEntry(),
+			"redmine_wiki_page":          resourceWikiPage(),
+			"redmine_version":            resourceVersion(),
+			"redmine_user":               resourceUser(),
+			"redmine_group":              resourceGroup(),
+			"redmine_group_membership":   resourceGroupMembership(),
+			"redmine_project_membership": resourceProjectMembership(),
+		},
+		DataSourcesMap: map[string]*schema.Resource{
+			"redmine_project":               dataSourceProject(),
+			"redmine_tracker":               dataSourceTracker(),
+			"redmine_trackers":              dataSourceTrackers(),
+			"redmine_issue_status":          dataSourceIssueStatus(),
+			"redmine_issue_statuses":        dataSourceIssueStatuses(),
+			"redmine_issue_priority":        dataSourceIssuePriority(),
+			"redmine_issue_priorities":      dataSourceIssuePriorities(),
+			"redmine_time_entry_activity":   dataSourceTimeEntryActivity(),
+			"redmine_time_entry_activities": dataSourceTimeEntryActivities(),
+			"redmine_time_entries":          dataSourceTimeEntries(),
 		},
-		DataSourcesMap:       map[string]*schema.Resource{},
 		ConfigureContextFunc: providerConfigure,
 	}
 }
@@ -52,6 +146,7 @@ func Provider() *schema.Provider {
 func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 	username := d.Get("username").(string)
 	password := d.Get("password").(string)
+	apiKey := d.Get("api_key").(string)
 	skipVerify := d.Get("skip_cert_verify").(bool)
 
 	var url string
@@ -61,16 +156,99 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 		url = uVal.(string)
 	}
 
-	client, err := redmine.NewClient(redmine.Config{
+	// the durations were validated before
+	minBackoff, _ := time.ParseDuration(d.Get("retry_min_backoff").(string))
+	maxBackoff, _ := time.ParseDuration(d.Get("retry_max_backoff").(string))
+
+	config := redmine.Config{
 		URL:            url,
 		Username:       username,
 		Password:       password,
+		APIKey:         apiKey,
 		SkipCertVerify: skipVerify,
-	})
+		Retry: redmine.RetryPolicy{
+			MaxAttempts:        d.Get("retry_max_attempts").(int),
+			MinBackoff:         minBackoff,
+			MaxBackoff:         maxBackoff,
+			RetryNonIdempotent: d.Get("retry_non_idempotent").(bool),
+		},
+		MaxRequestsPerSecond:  d.Get("max_requests_per_second").(float64),
+		MaxConcurrentRequests: d.Get("max_concurrent_requests").(int),
+	}
+
+	err := tlsConfigFromState(d, &config)
+	var client *redmine.Client
+	if err == nil {
+		client, err = redmine.NewClient(config)
+	}
 
 	if err != nil {
-		return nil, diag.FromErr(err)
+		return nil, diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "Unable to create Redmine client",
+			Detail:   err.Error(),
+		}}
 	}
 
 	return client, nil
 }
+
+// tlsConfigFromState adds the configured CA certificates, the client certificate and the minimum TLS version to the
+// client configuration.
+func tlsConfigFromState(d *schema.ResourceData, config *redmine.Config) error {
+	caCertFile := d.Get("ca_cert_file").(string)
+	caCertPEM := d.Get("ca_cert_pem").(string)
+	if caCertFile != "" && caCertPEM != "" {
+		return errors.New("ambiguous CA certificates: either ca_cert_file or ca_cert_pem must be configured, but not both")
+	}
+	if caCertFile != "" {
+		content, err := ioutil.ReadFile(caCertFile)
+		if err != nil {
+			return errors.Wrap(err, "could not read ca_cert_file")
+		}
+		caCertPEM = string(content)
+	}
+
+	clientCertPEM, err := readPEM(d.Get("client_cert").(string))
+	if err != nil {
+		return errors.Wrap(err, "could not read client_cert")
+	}
+	clientKeyPEM, err := readPEM(d.Get("client_key").(string))
+	if err != nil {
+		return errors.Wrap(err, "could not read client_key")
+	}
+
+	config.CACertPEM = caCertPEM
+	config.ClientCertPEM = clientCertPEM
+	config.ClientKeyPEM = clientKeyPEM
+	config.MinTLSVersion = redmine.TLSVersions[d.Get("min_tls_version").(string)]
+
+	return nil
+}
+
+// readPEM returns the given value if it is PEM encoded and otherwise the content of the file it points to.
+func readPEM(value string) (string, error) {
+	if value == "" || strings.Contains(value, "-----BEGIN") {
+		return value, nil
+	}
+
+	content, err := ioutil.ReadFile(value)
+	if err != nil {
+		return "", err
+	}
+
+	return string(content), nil
+}
+
+// validateDuration validates that a string contains a duration like "1s" or "2m30s".
+func validateDuration(i interface{}, key string) ([]string, []error) {
+	value, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", key)}
+	}
+	if _, err := time.ParseDuration(value); err != nil {
+		return nil, []error{fmt.Errorf("%s must be a duration like \"30s\" but is %q", key, value)}
+	}
+
+	return nil, nil
+}
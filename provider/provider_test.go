@@ -1,9 +1,16 @@
 package provider
 
 import (
+	"context"
+	"crypto/tls"
+	"io/ioutil"
+	"os"
 	"testing"
 
+	"github.com/cloudogu/terraform-provider-redmine/redmine"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var testAccProviders map[string]func() (*schema.Provider, error)
@@ -27,3 +34,135 @@ func TestProvider(t *testing.T) {
 func TestProvider_impl(t *testing.T) {
 	var _ *schema.Provider = Provider()
 }
+
+func TestProvider_resourceTimeouts(t *testing.T) {
+	for name, resource := range Provider().ResourcesMap {
+		if resource.Timeouts == nil {
+			t.Errorf("resource %s does not support a timeouts block", name)
+		}
+	}
+}
+
+// clearCredentialsEnv removes the credentials of the acceptance tests from the environment until the test ends so
+// that only the configured credentials are used.
+func clearCredentialsEnv(t *testing.T) {
+	for _, name := range []string{"REDMINE_API_KEY", "REDMINE_USERNAME", "REDMINE_PASSWORD"} {
+		name := name
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			continue
+		}
+		require.NoError(t, os.Unsetenv(name))
+		t.Cleanup(func() {
+			_ = os.Setenv(name, value)
+		})
+	}
+}
+
+func Test_providerConfigure(t *testing.T) {
+	t.Run("should create client with retry policy", func(t *testing.T) {
+		clearCredentialsEnv(t)
+		d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+			"api_key":            "secret",
+			"retry_max_attempts": 5,
+			"retry_min_backoff":  "500ms",
+			"retry_max_backoff":  "1m",
+		})
+
+		client, diags := providerConfigure(context.Background(), d)
+
+		require.False(t, diags.HasError(), diags)
+		assert.NotNil(t, client)
+	})
+	t.Run("should create client with request limits", func(t *testing.T) {
+		clearCredentialsEnv(t)
+		d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+			"api_key":                 "secret",
+			"max_requests_per_second": 2.5,
+			"max_concurrent_requests": 4,
+		})
+
+		client, diags := providerConfigure(context.Background(), d)
+
+		require.False(t, diags.HasError(), diags)
+		assert.NotNil(t, client)
+	})
+	t.Run("should fail if the backoff ends before it starts", func(t *testing.T) {
+		clearCredentialsEnv(t)
+		d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+			"api_key":           "secret",
+			"retry_min_backoff": "1m",
+			"retry_max_backoff": "1s",
+		})
+
+		_, diags := providerConfigure(context.Background(), d)
+
+		require.True(t, diags.HasError())
+		assert.Contains(t, diags[0].Detail, "invalid retry policy: backoff must be between 0 and 1s but starts at 1m0s")
+	})
+}
+
+func Test_tlsConfigFromState(t *testing.T) {
+	t.Run("should set TLS configuration", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+			"ca_cert_pem":     testPEM,
+			"min_tls_version": "1.3",
+		})
+		config := redmine.Config{}
+
+		err := tlsConfigFromState(d, &config)
+
+		require.NoError(t, err)
+		assert.Equal(t, testPEM, config.CACertPEM)
+		assert.Equal(t, uint16(tls.VersionTLS13), config.MinTLSVersion)
+	})
+	t.Run("should fail for CA certificate file and PEM", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+			"ca_cert_file": "/etc/ssl/ca.pem",
+			"ca_cert_pem":  testPEM,
+		})
+
+		err := tlsConfigFromState(d, &redmine.Config{})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "ambiguous CA certificates")
+	})
+}
+
+const testPEM = "-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----\n"
+
+func Test_readPEM(t *testing.T) {
+	t.Run("should return PEM encoded value", func(t *testing.T) {
+		actual, err := readPEM(testPEM)
+
+		require.NoError(t, err)
+		assert.Equal(t, testPEM, actual)
+	})
+	t.Run("should read file", func(t *testing.T) {
+		file, err := ioutil.TempFile("", "client-*.pem")
+		require.NoError(t, err)
+		defer os.Remove(file.Name())
+		_, err = file.WriteString(testPEM)
+		require.NoError(t, err)
+		require.NoError(t, file.Close())
+
+		actual, err := readPEM(file.Name())
+
+		require.NoError(t, err)
+		assert.Equal(t, testPEM, actual)
+	})
+	t.Run("should fail for missing file", func(t *testing.T) {
+		_, err := readPEM("/does/not/exist.pem")
+
+		require.Error(t, err)
+	})
+}
+
+func Test_validateDuration(t *testing.T) {
+	_, errs := validateDuration("2m30s", "retry_max_backoff")
+	assert.Empty(t, errs)
+
+	_, errs = validateDuration("30", "retry_max_backoff")
+	require.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], `retry_max_backoff must be a duration like "30s" but is "30"`)
+}
@@ -0,0 +1,189 @@
+package provider
+
+import (
+	"context"
+	"github.com/cloudogu/terraform-provider-redmine/redmine"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+	"log"
+)
+
+const (
+	GrpID          = "id"
+	GrpName        = "name"
+	GrpUserIDs     = "user_ids"
+	GrpCustomField = "custom_field"
+)
+
+// GroupClient provides methods for reading and modifying Redmine groups.
+type GroupClient interface {
+	// CreateGroup creates a group.
+	CreateGroup(ctx context.Context, group *redmine.Group) (*redmine.Group, error)
+	// ReadGroup reads a group including its users identified by the id. The id must not be empty string or "0".
+	ReadGroup(ctx context.Context, id string) (*redmine.Group, error)
+	// UpdateGroup updates an existing group. The group's users are only changed if the user IDs are not nil.
+	UpdateGroup(ctx context.Context, group *redmine.Group) (*redmine.Group, error)
+	// DeleteGroup deletes a group identified by the id. The id must not be empty string or "0".
+	DeleteGroup(ctx context.Context, id string) error
+}
+
+func resourceGroup() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceGroupCreate,
+		ReadContext:   resourceGroupRead,
+		UpdateContext: resourceGroupUpdate,
+		DeleteContext: resourceGroupDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceGroupImport,
+		},
+		Timeouts: resourceTimeouts(),
+		Schema: map[string]*schema.Schema{
+			GrpID: {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			GrpName: {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			// user_ids manages the group's users authoritatively if it is configured. Leave it out when the users are
+			// managed with redmine_group_membership resources instead, otherwise both will remove each other's users.
+			GrpUserIDs: {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+			GrpCustomField: customFieldSchema(),
+		},
+	}
+}
+
+func resourceGroupRead(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
+	groupID := d.Get(GrpID).(string)
+
+	client := i.(GroupClient)
+	group, err := client.ReadGroup(ctx, groupID)
+	if err != nil {
+		return handleReadError(d, "group", err)
+	}
+
+	log.Printf("group read id %s, name %s", group.ID, group.Name)
+
+	return groupSetToState(group, d)
+}
+
+func resourceGroupCreate(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := i.(GroupClient)
+
+	group := groupFromState(d)
+	if _, ok := d.GetOk(GrpUserIDs); !ok {
+		group.UserIDs = nil
+	}
+
+	createdGroup, err := client.CreateGroup(ctx, group)
+	if err != nil {
+		return errorToDiags(err, resourceGroup().Schema)
+	}
+
+	d.SetId(createdGroup.ID)
+
+	log.Printf("group create id %s, name %s", createdGroup.ID, group.Name)
+
+	diagRead := resourceGroupRead(ctx, d, i)
+	diags = append(diags, diagRead...)
+
+	return diags
+}
+
+func resourceGroupUpdate(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := i.(GroupClient)
+
+	group := groupFromState(d)
+	// do not replace users that were added by other means if user_ids is not configured
+	if !d.HasChange(GrpUserIDs) {
+		group.UserIDs = nil
+	}
+
+	_, err := client.UpdateGroup(ctx, group)
+	if err != nil {
+		return errorToDiags(err, resourceGroup().Schema)
+	}
+
+	log.Printf("group update id %s, name %s", group.ID, group.Name)
+
+	diagRead := resourceGroupRead(ctx, d, i)
+	diags = append(diags, diagRead...)
+
+	return diags
+}
+
+func resourceGroupDelete(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := i.(GroupClient)
+
+	groupID := d.Id()
+	err := client.DeleteGroup(ctx, groupID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("group delete id %s", groupID)
+
+	return diags
+}
+
+// resourceGroupImport imports a group by its numeric ID.
+func resourceGroupImport(ctx context.Context, d *schema.ResourceData, i interface{}) ([]*schema.ResourceData, error) {
+	importID := d.Id()
+	if err := verifyNumericImportID("group", importID); err != nil {
+		return nil, err
+	}
+
+	client := i.(GroupClient)
+	group, err := client.ReadGroup(ctx, importID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not import group '%s'", importID)
+	}
+
+	if err := diagsToError(groupSetToState(group, d)); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func groupSetToState(group *redmine.Group, d *schema.ResourceData) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	d.SetId(group.ID)
+	if err := d.Set(GrpName, group.Name); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(GrpUserIDs, group.UserIDs); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return append(diags, customFieldsSetToState(group.CustomFields, GrpCustomField, d)...)
+}
+
+func groupFromState(d *schema.ResourceData) *redmine.Group {
+	group := &redmine.Group{}
+	group.Name = d.Get(GrpName).(string)
+	group.CustomFields = customFieldsFromState(d, GrpCustomField)
+
+	group.UserIDs = []int{}
+	for _, userID := range d.Get(GrpUserIDs).(*schema.Set).List() {
+		group.UserIDs = append(group.UserIDs, userID.(int))
+	}
+
+	groupID := d.Id()
+	if groupID != "" && groupID != "0" {
+		group.ID = groupID
+	}
+
+	return group
+}
@@ -0,0 +1,180 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/cloudogu/terraform-provider-redmine/redmine"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+	"log"
+	"strconv"
+	"strings"
+)
+
+const (
+	GrpMemID      = "id"
+	GrpMemGroupID = "group_id"
+	GrpMemUserID  = "user_id"
+)
+
+// GroupMembershipClient provides methods for adding single users to Redmine groups and removing them again.
+type GroupMembershipClient interface {
+	// ReadGroup reads a group including its users identified by the id. The id must not be empty string or "0".
+	ReadGroup(ctx context.Context, id string) (*redmine.Group, error)
+	// AddGroupUser adds a user to a group identified by the id. The group's other users are not changed.
+	AddGroupUser(ctx context.Context, groupID string, userID int) error
+	// RemoveGroupUser removes a user from a group identified by the id. The group's other users are not changed.
+	RemoveGroupUser(ctx context.Context, groupID string, userID int) error
+}
+
+// resourceGroupMembership manages the membership of a single user in a group. Unlike the user_ids of redmine_group it
+// leaves all other users of the group untouched so that several modules can add users to the same group.
+func resourceGroupMembership() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceGroupMembershipCreate,
+		ReadContext:   resourceGroupMembershipRead,
+		DeleteContext: resourceGroupMembershipDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceGroupMembershipImport,
+		},
+		Timeouts: resourceTimeoutsWithoutUpdate(),
+		Schema: map[string]*schema.Schema{
+			GrpMemID: {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			GrpMemGroupID: {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			GrpMemUserID: {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceGroupMembershipRead(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	groupID := strconv.Itoa(d.Get(GrpMemGroupID).(int))
+	userID := d.Get(GrpMemUserID).(int)
+
+	client := i.(GroupMembershipClient)
+	group, err := client.ReadGroup(ctx, groupID)
+	if err != nil {
+		return handleReadError(d, "group membership", err)
+	}
+
+	if !containsInt(group.UserIDs, userID) {
+		log.Printf("[WARN] user %d is no longer a member of group %s, removing the group membership from state", userID, groupID)
+		d.SetId("")
+		return diags
+	}
+
+	log.Printf("group membership read group id %s, user id %d", groupID, userID)
+
+	return diags
+}
+
+func resourceGroupMembershipCreate(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := i.(GroupMembershipClient)
+
+	groupID := strconv.Itoa(d.Get(GrpMemGroupID).(int))
+	userID := d.Get(GrpMemUserID).(int)
+
+	err := client.AddGroupUser(ctx, groupID, userID)
+	if err != nil {
+		return errorToDiags(err, resourceGroupMembership().Schema)
+	}
+
+	d.SetId(groupMembershipID(groupID, userID))
+
+	log.Printf("group membership create group id %s, user id %d", groupID, userID)
+
+	diagRead := resourceGroupMembershipRead(ctx, d, i)
+	diags = append(diags, diagRead...)
+
+	return diags
+}
+
+func resourceGroupMembershipDelete(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := i.(GroupMembershipClient)
+
+	groupID := strconv.Itoa(d.Get(GrpMemGroupID).(int))
+	userID := d.Get(GrpMemUserID).(int)
+
+	err := client.RemoveGroupUser(ctx, groupID, userID)
+	// the user may have been removed from the group or the group may have been deleted outside of Terraform
+	if err != nil && !redmine.IsNotFound(err) {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("group membership delete group id %s, user id %d", groupID, userID)
+
+	return diags
+}
+
+// resourceGroupMembershipImport imports a group membership by an ID of the form <group_id>/<user_id>.
+func resourceGroupMembershipImport(ctx context.Context, d *schema.ResourceData, i interface{}) ([]*schema.ResourceData, error) {
+	importID := d.Id()
+	groupIDInt, userID, err := parseGroupMembershipID(importID)
+	if err != nil {
+		return nil, err
+	}
+	groupID := strconv.Itoa(groupIDInt)
+
+	client := i.(GroupMembershipClient)
+	group, err := client.ReadGroup(ctx, groupID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not import group membership '%s'", importID)
+	}
+	if !containsInt(group.UserIDs, userID) {
+		return nil, fmt.Errorf("could not import group membership '%s': user %d is not a member of group %s", importID, userID, groupID)
+	}
+
+	if err := d.Set(GrpMemGroupID, groupIDInt); err != nil {
+		return nil, err
+	}
+	if err := d.Set(GrpMemUserID, userID); err != nil {
+		return nil, err
+	}
+	d.SetId(groupMembershipID(groupID, userID))
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func groupMembershipID(groupID string, userID int) string {
+	return fmt.Sprintf("%s/%d", groupID, userID)
+}
+
+// parseGroupMembershipID splits an ID of the form <group_id>/<user_id> into its numeric parts.
+func parseGroupMembershipID(id string) (groupID int, userID int, err error) {
+	parts := strings.Split(id, "/")
+	if len(parts) == 2 {
+		groupID, err = strconv.Atoi(parts[0])
+		if err == nil {
+			userID, err = strconv.Atoi(parts[1])
+		}
+	}
+	if len(parts) != 2 || err != nil || groupID <= 0 || userID <= 0 {
+		return 0, 0, fmt.Errorf("could not import group membership: expected an ID of the form <group_id>/<user_id> but found '%s'", id)
+	}
+
+	return groupID, userID, nil
+}
+
+func containsInt(values []int, wanted int) bool {
+	for _, value := range values {
+		if value == wanted {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,149 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/cloudogu/terraform-provider-redmine/redmine"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+const (
+	testGroupMembershipTFResourceType = "redmine_group_membership"
+	testGroupMembershipTFResourceName = "test_group_membership1"
+	testGroupMembershipTFResource     = testGroupMembershipTFResourceType + "." + testGroupMembershipTFResourceName
+)
+
+const (
+	grpMemKeyID      = "id"
+	grpMemKeyGroupID = "group_id"
+	grpMemKeyUserID  = "user_id"
+)
+
+func TestAccGroupMembershipCreate_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckGroupMembershipDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: groupWithMembershipAsHCL(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(testGroupMembershipTFResource, grpMemKeyID),
+					resource.TestCheckResourceAttrPair(testGroupMembershipTFResource, grpMemKeyGroupID, testGroupTFResource, grpKeyID),
+					resource.TestCheckResourceAttrPair(testGroupMembershipTFResource, grpMemKeyUserID, testUserTFResource, usrKeyID),
+				),
+			},
+			{
+				// the group must not remove the user that was added by the membership
+				Config:   groupWithMembershipAsHCL(),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccGroupMembershipImport(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckGroupMembershipDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: groupWithMembershipAsHCL(),
+			},
+			{
+				ResourceName:      testGroupMembershipTFResource,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:  testGroupMembershipTFResource,
+				ImportState:   true,
+				ImportStateId: "developers",
+				ExpectError:   regexp.MustCompile("expected an ID of the form <group_id>/<user_id>"),
+			},
+		},
+	})
+}
+
+func testAccCheckGroupMembershipDestroy(s *terraform.State) error {
+	cli := testAccProvider.Meta().(*redmine.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != testGroupMembershipTFResourceType {
+			continue
+		}
+
+		groupID := rs.Primary.Attributes[grpMemKeyGroupID]
+		userID, _ := strconv.Atoi(rs.Primary.Attributes[grpMemKeyUserID])
+
+		// when
+		group, err := cli.ReadGroup(context.Background(), groupID)
+
+		// then
+		if err == nil {
+			if containsInt(group.UserIDs, userID) {
+				return fmt.Errorf("group membership (%s) still exists", rs.Primary.ID)
+			}
+
+			continue
+		}
+
+		if !redmine.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func groupWithMembershipAsHCL() string {
+	return userAsHCL(testUserTFResourceName, "jdoe", "Jane", "Doe", "jdoe@example.com", false, "active") + "\n" +
+		groupAsHCL(testGroupTFResourceName, "developers", "") + "\n" +
+		fmt.Sprintf(`resource "%s" "%s" {
+  group_id = %s.id
+  user_id = %s.id
+}`, testGroupMembershipTFResourceType, testGroupMembershipTFResourceName, testGroupTFResource, testUserTFResource)
+}
+
+func Test_resourceGroupMembershipDelete(t *testing.T) {
+	newResourceData := func(t *testing.T) *schema.ResourceData {
+		d := schema.TestResourceDataRaw(t, resourceGroupMembership().Schema, map[string]interface{}{
+			GrpMemGroupID: 4,
+			GrpMemUserID:  5,
+		})
+		d.SetId(groupMembershipID("4", 5))
+		return d
+	}
+
+	t.Run("should succeed if the membership was already removed", func(t *testing.T) {
+		client := &fakeGroupMembershipClient{removeErr: &redmine.NotFoundError{}}
+
+		diags := resourceGroupMembershipDelete(context.Background(), newResourceData(t), client)
+
+		assert.False(t, diags.HasError())
+		assert.Equal(t, []string{"4-5"}, client.removed)
+	})
+	t.Run("should fail for other errors", func(t *testing.T) {
+		client := &fakeGroupMembershipClient{removeErr: &redmine.ForbiddenError{}}
+
+		diags := resourceGroupMembershipDelete(context.Background(), newResourceData(t), client)
+
+		assert.True(t, diags.HasError())
+	})
+}
+
+type fakeGroupMembershipClient struct {
+	GroupMembershipClient
+	removeErr error
+	removed   []string
+}
+
+func (c *fakeGroupMembershipClient) RemoveGroupUser(_ context.Context, groupID string, userID int) error {
+	c.removed = append(c.removed, fmt.Sprintf("%s-%d", groupID, userID))
+	return c.removeErr
+}
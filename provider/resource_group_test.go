@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/cloudogu/terraform-provider-redmine/redmine"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"testing"
+)
+
+const (
+	testGroupTFResourceType = "redmine_group"
+	testGroupTFResourceName = "testgroup1"
+	testGroupTFResource     = testGroupTFResourceType + "." + testGroupTFResourceName
+)
+
+const (
+	grpKeyID      = "id"
+	grpKeyName    = "name"
+	grpKeyUserIDs = "user_ids"
+)
+
+func TestAccGroupCreate_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: groupAsHCL(testGroupTFResourceName, "developers", ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(testGroupTFResource, grpKeyID),
+					resource.TestCheckResourceAttr(testGroupTFResource, grpKeyName, "developers"),
+					resource.TestCheckResourceAttr(testGroupTFResource, grpKeyUserIDs+".#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccGroupUpdate_usersChanged(t *testing.T) {
+	userIDReference := testUserTFResource + ".id"
+	tfUserBlock := userAsHCL(testUserTFResourceName, "jdoe", "Jane", "Doe", "jdoe@example.com", false, "active") + "\n"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: tfUserBlock + groupAsHCL(testGroupTFResourceName, "developers", "[]"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(testGroupTFResource, grpKeyUserIDs+".#", "0"),
+				),
+			},
+			{
+				Config: tfUserBlock + groupAsHCL(testGroupTFResourceName, "renamed developers", "["+userIDReference+"]"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(testGroupTFResource, grpKeyName, "renamed developers"),
+					resource.TestCheckResourceAttr(testGroupTFResource, grpKeyUserIDs+".#", "1"),
+				),
+			},
+			{
+				Config: tfUserBlock + groupAsHCL(testGroupTFResourceName, "renamed developers", "[]"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(testGroupTFResource, grpKeyUserIDs+".#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccGroupImport(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: groupAsHCL(testGroupTFResourceName, "developers", ""),
+			},
+			{
+				ResourceName:      testGroupTFResource,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckGroupDestroy(s *terraform.State) error {
+	cli := testAccProvider.Meta().(*redmine.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != testGroupTFResourceType {
+			continue
+		}
+
+		groupID := rs.Primary.ID
+
+		// when
+		group, err := cli.ReadGroup(context.Background(), groupID)
+
+		// then
+		if err == nil {
+			if group.ID != "" {
+				return fmt.Errorf("group (%s) still exists", rs.Primary.ID)
+			}
+
+			return nil
+		}
+
+		if !redmine.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// groupAsHCL renders a group. The user IDs are left out if userIDs is empty so that the group does not manage its
+// users.
+func groupAsHCL(tfName, name, userIDs string) string {
+	userIDsAttribute := ""
+	if userIDs != "" {
+		userIDsAttribute = "\n  user_ids = " + userIDs
+	}
+
+	return fmt.Sprintf(`resource "%s" "%s" {
+  name = "%s"%s
+}`, testGroupTFResourceType, tfName, name, userIDsAttribute)
+}
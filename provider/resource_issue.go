@@ -5,20 +5,45 @@ import (
 	"github.com/cloudogu/terraform-provider-redmine/redmine"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/pkg/errors"
 	"log"
 )
 
 const (
-	IssID            = "id"
-	IssProjectID     = "project_id"
-	IssTrackerID     = "tracker_id"
-	IssSubject       = "subject"
-	IssDescription   = "description"
-	IssParentIssueID = "parent_issue_id"
-	IssPriorityID    = "priority_id"
-	IssCategoryID    = "category_id"
-	IssCreatedOn     = "created_on"
-	IssUpdatedOn     = "updated_on"
+	IssID             = "id"
+	IssProjectID      = "project_id"
+	IssTrackerID      = "tracker_id"
+	IssSubject        = "subject"
+	IssDescription    = "description"
+	IssParentIssueID  = "parent_issue_id"
+	IssPriorityID     = "priority_id"
+	IssCategoryID     = "category_id"
+	IssAssignedToID   = "assigned_to_id"
+	IssStatusID       = "status_id"
+	IssStartDate      = "start_date"
+	IssDueDate        = "due_date"
+	IssDoneRatio      = "done_ratio"
+	IssEstimatedHours = "estimated_hours"
+	IssIsPrivate      = "is_private"
+	IssFixedVersionID = "fixed_version_id"
+	IssCreatedOn      = "created_on"
+	IssUpdatedOn      = "updated_on"
+	IssCustomField    = "custom_field"
+	IssAttachment     = "attachment"
+	IssWatcherUserIDs = "watcher_user_ids"
+	IssUpdateNote     = "update_note"
+	IssPrivateNotes   = "private_notes"
+	IssJournals       = "journals"
+)
+
+const (
+	JrnID           = "id"
+	JrnUserID       = "user_id"
+	JrnUserName     = "user_name"
+	JrnNotes        = "notes"
+	JrnPrivateNotes = "private_notes"
+	JrnCreatedOn    = "created_on"
 )
 
 // IssueClient provides methods for reading and modifying Redmine issues.
@@ -39,6 +64,12 @@ func resourceIssue() *schema.Resource {
 		ReadContext:   resourceIssueRead,
 		UpdateContext: resourceIssueUpdate,
 		DeleteContext: resourceIssueDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceIssueImport,
+		},
+		Timeouts: resourceTimeouts(),
+		// an edited attachment file updates the issue although the configuration did not change
+		CustomizeDiff: customizeAttachmentDiff(IssAttachment, IssUpdatedOn),
 		Schema: map[string]*schema.Schema{
 			IssID: {
 				Type:     schema.TypeString,
@@ -73,6 +104,49 @@ func resourceIssue() *schema.Resource {
 				Type:     schema.TypeInt,
 				Optional: true,
 			},
+			IssAssignedToID: {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			// Redmine uses the tracker's default status if the status is not configured
+			IssStatusID: {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			// Redmine may set the start date to the creation date if it is not configured
+			IssStartDate: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringMatch(dueDateYYYYMMDDRegexp, "invalid start date found; expected either empty string or formatted date (YYYY-MM-DD)"),
+			},
+			IssDueDate: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringMatch(dueDateYYYYMMDDRegexp, "invalid due date found; expected either empty string or formatted date (YYYY-MM-DD)"),
+			},
+			// Redmine may calculate the done ratio from the issue status depending on its settings
+			IssDoneRatio: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntBetween(0, 100),
+			},
+			IssEstimatedHours: {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				ValidateFunc: validation.FloatAtLeast(0),
+			},
+			IssIsPrivate: {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			IssFixedVersionID: {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
 			IssCreatedOn: {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -83,6 +157,57 @@ func resourceIssue() *schema.Resource {
 				Optional: true,
 				Computed: true,
 			},
+			IssCustomField: customFieldSchema(),
+			IssAttachment:  attachmentSchema(),
+			// the watchers are only managed if they are configured, otherwise watchers added by Redmine or in the
+			// browser are kept. An empty set removes the watchers of a managed issue.
+			IssWatcherUserIDs: {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+			// update_note is added to the issue's history whenever Terraform updates the issue. It is kept as
+			// configured because Redmine stores it in a new journal entry.
+			IssUpdateNote: {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			IssPrivateNotes: {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			IssJournals: {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						JrnID: {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						JrnUserID: {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						JrnUserName: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						JrnNotes: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						JrnPrivateNotes: {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						JrnCreatedOn: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -93,7 +218,7 @@ func resourceIssueRead(ctx context.Context, d *schema.ResourceData, i interface{
 	client := i.(IssueClient)
 	issue, err := client.ReadIssue(ctx, issueID)
 	if err != nil {
-		return diag.FromErr(err)
+		return handleReadError(d, "issue", err)
 	}
 
 	log.Printf("issue read id %s, project %d", issue.ID, issue.ProjectID)
@@ -106,13 +231,26 @@ func resourceIssueCreate(ctx context.Context, d *schema.ResourceData, i interfac
 	client := i.(IssueClient)
 
 	issue := issueFromState(d)
+	if _, ok := d.GetOk(IssWatcherUserIDs); !ok {
+		issue.WatcherUserIDs = nil
+	}
 
-	createdIssue, err := client.CreateIssue(ctx, issue)
+	attachments := attachmentsFromList(d.Get(IssAttachment).([]interface{}))
+	uploads, err := uploadAttachments(ctx, i.(AttachmentClient), attachments)
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	issue.Uploads = uploads
+
+	createdIssue, err := client.CreateIssue(ctx, issue)
+	if err != nil {
+		return errorToDiags(err, resourceIssue().Schema)
+	}
 
 	d.SetId(createdIssue.ID)
+	if err := d.Set(IssAttachment, attachmentsToList(attachments)); err != nil {
+		return diag.FromErr(err)
+	}
 
 	log.Printf("issue create id %s, project %d", issue.ID, issue.ProjectID)
 
@@ -127,9 +265,28 @@ func resourceIssueUpdate(ctx context.Context, d *schema.ResourceData, i interfac
 	client := i.(IssueClient)
 
 	issue := issueFromState(d)
+	if !d.HasChange(IssWatcherUserIDs) {
+		issue.WatcherUserIDs = nil
+	}
+	issue.Notes = d.Get(IssUpdateNote).(string)
+	issue.PrivateNotes = d.Get(IssPrivateNotes).(bool)
+
+	oldAttachments, newAttachments := d.GetChange(IssAttachment)
+	attachments := attachmentsFromList(newAttachments.([]interface{}))
+	if d.HasChange(IssAttachment) || len(attachments) > 0 {
+		uploads, err := updateIssueAttachments(ctx, i, issue.ID, attachments, attachmentsFromList(oldAttachments.([]interface{})))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		issue.Uploads = uploads
+	}
 
 	_, err := client.UpdateIssue(ctx, issue)
 	if err != nil {
+		return errorToDiags(err, resourceIssue().Schema)
+	}
+
+	if err := d.Set(IssAttachment, attachmentsToList(attachments)); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -141,6 +298,23 @@ func resourceIssueUpdate(ctx context.Context, d *schema.ResourceData, i interfac
 	return diags
 }
 
+// updateIssueAttachments deletes and updates the issue's existing attachments and uploads the new or changed ones. The
+// returned uploads must be sent with the issue.
+func updateIssueAttachments(ctx context.Context, i interface{}, issueID string, attachments, previous []*attachment) ([]redmine.Upload, error) {
+	existingIssue, err := i.(IssueClient).ReadIssue(ctx, issueID)
+	if err != nil {
+		return nil, err
+	}
+
+	attachmentClient := i.(AttachmentClient)
+	toUpload, err := syncAttachments(ctx, attachmentClient, attachments, previous, existingIssue.Attachments)
+	if err != nil {
+		return nil, err
+	}
+
+	return uploadAttachments(ctx, attachmentClient, toUpload)
+}
+
 func resourceIssueDelete(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	client := i.(IssueClient)
@@ -156,6 +330,26 @@ func resourceIssueDelete(ctx context.Context, d *schema.ResourceData, i interfac
 	return diags
 }
 
+// resourceIssueImport imports an issue by its numeric ID.
+func resourceIssueImport(ctx context.Context, d *schema.ResourceData, i interface{}) ([]*schema.ResourceData, error) {
+	importID := d.Id()
+	if err := verifyNumericImportID("issue", importID); err != nil {
+		return nil, err
+	}
+
+	client := i.(IssueClient)
+	issue, err := client.ReadIssue(ctx, importID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not import issue '%s'", importID)
+	}
+
+	if err := diagsToError(issueSetToState(issue, d)); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
 func issueSetToState(issue *redmine.Issue, d *schema.ResourceData) diag.Diagnostics {
 	var diags diag.Diagnostics
 
@@ -181,23 +375,93 @@ func issueSetToState(issue *redmine.Issue, d *schema.ResourceData) diag.Diagnost
 	if err := d.Set(IssCategoryID, issue.CategoryID); err != nil {
 		return diag.FromErr(err)
 	}
+	if err := d.Set(IssAssignedToID, issue.AssignedToID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(IssStatusID, issue.StatusID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(IssStartDate, issue.StartDate); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(IssDueDate, issue.DueDate); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(IssDoneRatio, issue.DoneRatio); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(IssEstimatedHours, issue.EstimatedHours); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(IssIsPrivate, issue.IsPrivate); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(IssFixedVersionID, issue.FixedVersionID); err != nil {
+		return diag.FromErr(err)
+	}
 	if err := d.Set(IssCreatedOn, issue.CreatedOn); err != nil {
 		return diag.FromErr(err)
 	}
 	if err := d.Set(IssUpdatedOn, issue.UpdatedOn); err != nil {
 		return diag.FromErr(err)
 	}
+	// unmanaged watchers are not read because they would lead to a diff that removes them
+	if d.Get(IssWatcherUserIDs).(*schema.Set).Len() > 0 {
+		if err := d.Set(IssWatcherUserIDs, issue.WatcherUserIDs); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if err := d.Set(IssJournals, journalsToState(issue.Journals)); err != nil {
+		return diag.FromErr(err)
+	}
+	attachments := attachmentsFromList(d.Get(IssAttachment).([]interface{}))
+	if err := attachmentsSetToState(attachments, issue.Attachments, IssAttachment, d); err != nil {
+		return diag.FromErr(err)
+	}
+	diags = append(diags, customFieldsSetToState(issue.CustomFields, IssCustomField, d)...)
 	return diags
 }
 
+func journalsToState(journals []redmine.Journal) []interface{} {
+	stateJournals := []interface{}{}
+	for _, journal := range journals {
+		stateJournals = append(stateJournals, map[string]interface{}{
+			JrnID:           journal.ID,
+			JrnUserID:       journal.UserID,
+			JrnUserName:     journal.UserName,
+			JrnNotes:        journal.Notes,
+			JrnPrivateNotes: journal.PrivateNotes,
+			JrnCreatedOn:    journal.CreatedOn,
+		})
+	}
+
+	return stateJournals
+}
+
 func issueFromState(d *schema.ResourceData) *redmine.Issue {
 	issue := &redmine.Issue{}
 	issue.ProjectID, _ = d.Get(IssProjectID).(int)
 	issue.TrackerID = d.Get(IssTrackerID).(int)
 	issue.Subject = d.Get(IssSubject).(string)
 	issue.Description = d.Get(IssDescription).(string)
+	// a zero parent issue ID removes the parent
+	issue.ParentIssueID = d.Get(IssParentIssueID).(int)
+	issue.AssignedToID = d.Get(IssAssignedToID).(int)
+	issue.StatusID = d.Get(IssStatusID).(int)
+	issue.StartDate = d.Get(IssStartDate).(string)
+	issue.DueDate = d.Get(IssDueDate).(string)
+	issue.DoneRatio = d.Get(IssDoneRatio).(int)
+	issue.EstimatedHours = d.Get(IssEstimatedHours).(float64)
+	issue.IsPrivate = d.Get(IssIsPrivate).(bool)
+	issue.FixedVersionID = d.Get(IssFixedVersionID).(int)
 	issue.CreatedOn = d.Get(IssCreatedOn).(string)
 	issue.UpdatedOn = d.Get(IssUpdatedOn).(string)
+	issue.CustomFields = customFieldsFromState(d, IssCustomField)
+
+	issue.WatcherUserIDs = []int{}
+	for _, userID := range d.Get(IssWatcherUserIDs).(*schema.Set).List() {
+		issue.WatcherUserIDs = append(issue.WatcherUserIDs, userID.(int))
+	}
 
 	issueID := d.Id()
 	if issueID != "" && issueID != "0" {
@@ -2,16 +2,21 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"github.com/cloudogu/terraform-provider-redmine/redmine"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
 	"log"
+	"strconv"
 )
 
 const (
-	IssCatID        = "id"
-	IssCatProjectID = "project_id"
-	IssCatName      = "name"
+	IssCatID           = "id"
+	IssCatProjectID    = "project_id"
+	IssCatName         = "name"
+	IssCatAssignedToID = "assigned_to_id"
 )
 
 // IssueCategoryClient provides methods for reading and modifying Redmine IssueCategories.
@@ -32,6 +37,11 @@ func resourceIssueCategory() *schema.Resource {
 		ReadContext:   resourceIssueCategoryRead,
 		UpdateContext: resourceIssueCategoryUpdate,
 		DeleteContext: resourceIssueCategoryDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceIssueCategoryImport,
+		},
+		Timeouts:      resourceTimeouts(),
+		CustomizeDiff: validateIssueCategoryAssignee,
 		Schema: map[string]*schema.Schema{
 			IssCatID: {
 				Type:     schema.TypeString,
@@ -45,17 +55,93 @@ func resourceIssueCategory() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			// new issues of the category are assigned to this user or group
+			IssCatAssignedToID: {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
 		},
 	}
 }
 
+// validateIssueCategoryAssignee checks during the plan that the assignee is a member of the category's project because
+// Redmine only allows project members as assignees. If the project or the assignee are not known yet, the check is left
+// to verifyIssueCategoryAssignee during the apply.
+func validateIssueCategoryAssignee(ctx context.Context, d *schema.ResourceDiff, i interface{}) error {
+	if !d.HasChange(IssCatAssignedToID) && !d.HasChange(IssCatProjectID) {
+		return nil
+	}
+	if !d.NewValueKnown(IssCatProjectID) || !d.NewValueKnown(IssCatAssignedToID) {
+		return nil
+	}
+
+	assigneeID := d.Get(IssCatAssignedToID).(int)
+	if assigneeID == 0 {
+		return nil
+	}
+
+	client, ok := i.(ProjectMembershipClient)
+	if !ok {
+		return nil
+	}
+
+	return verifyProjectMember(ctx, client, d.Get(IssCatProjectID).(int), assigneeID)
+}
+
+// verifyIssueCategoryAssignee checks before a category is sent to Redmine that the assignee is a member of the
+// category's project. It is the fallback for projects and assignees that were not known during the plan.
+func verifyIssueCategoryAssignee(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
+	if !d.HasChanges(IssCatAssignedToID, IssCatProjectID) {
+		return nil
+	}
+
+	assigneeID := d.Get(IssCatAssignedToID).(int)
+	if assigneeID == 0 {
+		return nil
+	}
+
+	client, ok := i.(ProjectMembershipClient)
+	if !ok {
+		return nil
+	}
+
+	if err := verifyProjectMember(ctx, client, d.Get(IssCatProjectID).(int), assigneeID); err != nil {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       err.Error(),
+			AttributePath: cty.GetAttrPath(IssCatAssignedToID),
+		}}
+	}
+
+	return nil
+}
+
+// verifyProjectMember returns an error if the user or group is not a member of the project, either directly or by
+// inheritance.
+func verifyProjectMember(ctx context.Context, client ProjectMembershipClient, projectID, principalID int) error {
+	memberships, err := client.ReadProjectMemberships(ctx, strconv.Itoa(projectID))
+	if err != nil {
+		return errors.Wrapf(err, "could not verify that the %s %d is a member of project %d", IssCatAssignedToID,
+			principalID, projectID)
+	}
+
+	for _, membership := range memberships {
+		if membership.UserID == principalID || membership.GroupID == principalID {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%s %d must be a member of project %d, f. i. with a redmine_project_membership",
+		IssCatAssignedToID, principalID, projectID)
+}
+
 func resourceIssueCategoryRead(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
 	IssueCategoryID := d.Get(IssID).(string)
 
 	client := i.(IssueCategoryClient)
 	IssueCategory, err := client.ReadIssueCategory(ctx, IssueCategoryID)
 	if err != nil {
-		return diag.FromErr(err)
+		return handleReadError(d, "issue category", err)
 	}
 
 	log.Printf("IssueCategory read id %s, project %d", IssueCategory.ID, IssueCategory.ProjectID)
@@ -67,11 +153,15 @@ func resourceIssueCategoryCreate(ctx context.Context, d *schema.ResourceData, i
 	var diags diag.Diagnostics
 	client := i.(IssueCategoryClient)
 
+	if assigneeDiags := verifyIssueCategoryAssignee(ctx, d, i); assigneeDiags.HasError() {
+		return assigneeDiags
+	}
+
 	IssueCategory := IssueCategoryFromState(d)
 
 	createdIssueCategory, err := client.CreateIssueCategory(ctx, IssueCategory)
 	if err != nil {
-		return diag.FromErr(err)
+		return errorToDiags(err, resourceIssueCategory().Schema)
 	}
 
 	d.SetId(createdIssueCategory.ID)
@@ -88,11 +178,15 @@ func resourceIssueCategoryUpdate(ctx context.Context, d *schema.ResourceData, i
 	var diags diag.Diagnostics
 	client := i.(IssueCategoryClient)
 
+	if assigneeDiags := verifyIssueCategoryAssignee(ctx, d, i); assigneeDiags.HasError() {
+		return assigneeDiags
+	}
+
 	IssueCategory := IssueCategoryFromState(d)
 
 	_, err := client.UpdateIssueCategory(ctx, IssueCategory)
 	if err != nil {
-		return diag.FromErr(err)
+		return errorToDiags(err, resourceIssueCategory().Schema)
 	}
 
 	log.Printf("IssueCategory update id %s, project %d", IssueCategory.ID, IssueCategory.ProjectID)
@@ -118,6 +212,26 @@ func resourceIssueCategoryDelete(ctx context.Context, d *schema.ResourceData, i
 	return diags
 }
 
+// resourceIssueCategoryImport imports an issue category by its numeric ID.
+func resourceIssueCategoryImport(ctx context.Context, d *schema.ResourceData, i interface{}) ([]*schema.ResourceData, error) {
+	importID := d.Id()
+	if err := verifyNumericImportID("issue category", importID); err != nil {
+		return nil, err
+	}
+
+	client := i.(IssueCategoryClient)
+	issueCategory, err := client.ReadIssueCategory(ctx, importID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not import issue category '%s'", importID)
+	}
+
+	if err := diagsToError(IssueCategorySetToState(issueCategory, d)); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
 func IssueCategorySetToState(IssueCategory *redmine.IssueCategory, d *schema.ResourceData) diag.Diagnostics {
 	var diags diag.Diagnostics
 
@@ -131,6 +245,9 @@ func IssueCategorySetToState(IssueCategory *redmine.IssueCategory, d *schema.Res
 	if err := d.Set(IssCatName, IssueCategory.Name); err != nil {
 		return diag.FromErr(err)
 	}
+	if err := d.Set(IssCatAssignedToID, IssueCategory.AssignedToID); err != nil {
+		return diag.FromErr(err)
+	}
 
 	return diags
 }
@@ -139,6 +256,7 @@ func IssueCategoryFromState(d *schema.ResourceData) *redmine.IssueCategory {
 	IssueCategory := &redmine.IssueCategory{}
 	IssueCategory.ProjectID, _ = d.Get(IssCatProjectID).(int)
 	IssueCategory.Name = d.Get(IssCatName).(string)
+	IssueCategory.AssignedToID = d.Get(IssCatAssignedToID).(int)
 
 	IssueCategoryID := d.Id()
 	if IssueCategoryID != "" && IssueCategoryID != "0" {
@@ -4,9 +4,13 @@ import (
 	"context"
 	"fmt"
 	"github.com/cloudogu/terraform-provider-redmine/redmine"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-	"strings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"regexp"
 	"testing"
 )
 
@@ -17,9 +21,10 @@ const (
 )
 
 const (
-	issCatKeyID        = "id"
-	issCatKeyProjectID = "project_id"
-	issCatKeyName      = "name"
+	issCatKeyID           = "id"
+	issCatKeyProjectID    = "project_id"
+	issCatKeyName         = "name"
+	issCatKeyAssignedToID = "assigned_to_id"
 )
 
 func TestAccIssueCategoryCreate_basic(t *testing.T) {
@@ -102,6 +107,27 @@ func TestAccIssueCategoryUpdate_categoryValuesChanged(t *testing.T) {
 	})
 }
 
+func TestAccIssueCategoryImport(t *testing.T) {
+	projectResourceIDReference := testProjectTFResource + ".id"
+	tfProjectAndIssueCategoryBlocks := basicProjectWithDescription("testproject", "project", "a project") + "\n" +
+		issueCategoryAsHCL(testIssueCategoryTFResourceName, projectResourceIDReference, "category name")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckIssueCategoryDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: tfProjectAndIssueCategoryBlocks,
+			},
+			{
+				ResourceName:      testIssueCategoryTFResource,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
 func testAccCheckIssueCategoryDestroy(s *terraform.State) error {
 	cli := testAccProvider.Meta().(*redmine.Client)
 
@@ -124,7 +150,7 @@ func testAccCheckIssueCategoryDestroy(s *terraform.State) error {
 			return nil
 		}
 
-		if !strings.Contains(err.Error(), "issue category (id: "+issueCategoryID+") was not found") {
+		if !redmine.IsNotFound(err) {
 			return err
 		}
 	}
@@ -132,6 +158,138 @@ func testAccCheckIssueCategoryDestroy(s *terraform.State) error {
 	return testAccCheckProjectDestroy(s)
 }
 
+func TestAccIssueCategoryUpdate_assignee(t *testing.T) {
+	categoryWithAssignee := func(assignee string) string {
+		return projectMembershipAsHCL(prjMemKeyUserID, testUserTFResource, prjMemValueRoleDeveloper) + "\n" +
+			userAsHCL("outsider", "outsider", "Otto", "Outsider", "outsider@example.com", false, "active") + "\n" +
+			fmt.Sprintf(`resource "%s" "%s" {
+  project_id = %s.id
+  name = "Backend"
+  assigned_to_id = %s
+  depends_on = [%s]
+}`, testIssueCategoryTFResourceType, testIssueCategoryTFResourceName, testProjectTFResource, assignee,
+				testProjectMembershipTFResource)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckIssueCategoryDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: categoryWithAssignee(testUserTFResource + ".id"),
+				Check: resource.TestCheckResourceAttrPair(testIssueCategoryTFResource, issCatKeyAssignedToID,
+					testUserTFResource, usrKeyID),
+			},
+			{
+				Config:      categoryWithAssignee(testUserTFResourceType + ".outsider.id"),
+				ExpectError: regexp.MustCompile("assigned_to_id [0-9]+ must be a member of project"),
+			},
+			{
+				Config: categoryWithAssignee("0"),
+				Check:  resource.TestCheckResourceAttr(testIssueCategoryTFResource, issCatKeyAssignedToID, "0"),
+			},
+		},
+	})
+}
+
+func Test_verifyProjectMember(t *testing.T) {
+	client := &fakeProjectMembershipClient{memberships: []*redmine.Membership{
+		{ID: "7", ProjectID: 2, UserID: 5, RoleIDs: []int{3}},
+		{ID: "8", ProjectID: 2, GroupID: 6, InheritedRoleIDs: []int{3}},
+	}}
+
+	assert.NoError(t, verifyProjectMember(context.Background(), client, 2, 5))
+	assert.NoError(t, verifyProjectMember(context.Background(), client, 2, 6))
+	assert.EqualError(t, verifyProjectMember(context.Background(), client, 2, 9),
+		"assigned_to_id 9 must be a member of project 2, f. i. with a redmine_project_membership")
+}
+
+func Test_resourceIssueCategoryCreate_assignee(t *testing.T) {
+	newClient := func() *fakeIssueCategoryClient {
+		return &fakeIssueCategoryClient{fakeProjectMembershipClient: fakeProjectMembershipClient{
+			memberships: []*redmine.Membership{{ID: "7", ProjectID: 2, UserID: 5, RoleIDs: []int{3}}},
+		}}
+	}
+
+	t.Run("should create category with member as assignee", func(t *testing.T) {
+		client := newClient()
+		d := schema.TestResourceDataRaw(t, resourceIssueCategory().Schema, map[string]interface{}{
+			IssCatProjectID: 2, IssCatName: "Backend", IssCatAssignedToID: 5,
+		})
+
+		diags := resourceIssueCategoryCreate(context.Background(), d, client)
+
+		require.False(t, diags.HasError(), diags)
+		require.Len(t, client.created, 1)
+		assert.Equal(t, 5, client.created[0].AssignedToID)
+	})
+	t.Run("should fail for assignee outside the project without sending the category", func(t *testing.T) {
+		client := newClient()
+		d := schema.TestResourceDataRaw(t, resourceIssueCategory().Schema, map[string]interface{}{
+			IssCatProjectID: 2, IssCatName: "Backend", IssCatAssignedToID: 9,
+		})
+
+		diags := resourceIssueCategoryCreate(context.Background(), d, client)
+
+		require.Len(t, diags, 1)
+		assert.Equal(t, cty.GetAttrPath(IssCatAssignedToID), diags[0].AttributePath)
+		assert.Equal(t, "assigned_to_id 9 must be a member of project 2, f. i. with a redmine_project_membership",
+			diags[0].Summary)
+		assert.Empty(t, client.created)
+	})
+}
+
+func Test_validateIssueCategoryAssignee(t *testing.T) {
+	client := &fakeIssueCategoryClient{fakeProjectMembershipClient: fakeProjectMembershipClient{
+		memberships: []*redmine.Membership{{ID: "7", ProjectID: 2, UserID: 5, RoleIDs: []int{3}}},
+	}}
+	categoryConfig := func(assigneeID interface{}) *terraform.ResourceConfig {
+		return terraform.NewResourceConfigRaw(map[string]interface{}{
+			IssCatProjectID: 2, IssCatName: "Backend", IssCatAssignedToID: assigneeID,
+		})
+	}
+
+	t.Run("should plan member as assignee", func(t *testing.T) {
+		_, err := resourceIssueCategory().Diff(context.Background(), nil, categoryConfig(5), client)
+
+		assert.NoError(t, err)
+	})
+	t.Run("should fail the plan for assignee outside the project", func(t *testing.T) {
+		_, err := resourceIssueCategory().Diff(context.Background(), nil, categoryConfig(9), client)
+
+		assert.EqualError(t, err,
+			"assigned_to_id 9 must be a member of project 2, f. i. with a redmine_project_membership")
+	})
+	t.Run("should leave an unknown assignee to the apply", func(t *testing.T) {
+		// the SDK's marker for values that are only known after the apply
+		unknown := "74D93920-ED26-11E3-AC10-0800200C9A66"
+
+		diff, err := resourceIssueCategory().Diff(context.Background(), nil, categoryConfig(unknown), client)
+
+		require.NoError(t, err)
+		assert.True(t, diff.Attributes[IssCatAssignedToID].NewComputed)
+	})
+}
+
+type fakeIssueCategoryClient struct {
+	IssueCategoryClient
+	fakeProjectMembershipClient
+	created []*redmine.IssueCategory
+}
+
+func (c *fakeIssueCategoryClient) CreateIssueCategory(_ context.Context, category *redmine.IssueCategory) (*redmine.IssueCategory, error) {
+	c.created = append(c.created, category)
+	created := *category
+	created.ID = "4"
+	return &created, nil
+}
+
+func (c *fakeIssueCategoryClient) ReadIssueCategory(_ context.Context, id string) (*redmine.IssueCategory, error) {
+	category := *c.created[len(c.created)-1]
+	category.ID = id
+	return &category, nil
+}
+
 func issueCategoryAsHCL(tfName, projectID string, name string) string {
 	return fmt.Sprintf(`resource "%s" "%s" {
   project_id = %s
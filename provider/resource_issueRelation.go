@@ -0,0 +1,206 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/cloudogu/terraform-provider-redmine/redmine"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/pkg/errors"
+	"log"
+)
+
+const (
+	IssRelID           = "id"
+	IssRelIssueID      = "issue_id"
+	IssRelIssueToID    = "issue_to_id"
+	IssRelRelationType = "relation_type"
+	IssRelDelay        = "delay"
+)
+
+// issueRelationTypes contains the relation types that Redmine supports.
+var issueRelationTypes = []string{"relates", "duplicates", "duplicated", "blocks", "blocked", "precedes", "follows",
+	"copied_to", "copied_from"}
+
+// IssueRelationClient provides methods for reading and modifying relations between Redmine issues.
+type IssueRelationClient interface {
+	// CreateIssueRelation creates a relation between two issues.
+	CreateIssueRelation(ctx context.Context, relation *redmine.IssueRelation) (*redmine.IssueRelation, error)
+	// ReadIssueRelation reads an issue relation identified by the id. The id must not be empty string or "0".
+	ReadIssueRelation(ctx context.Context, id string) (*redmine.IssueRelation, error)
+	// DeleteIssueRelation deletes an issue relation identified by the id. The id must not be empty string or "0".
+	DeleteIssueRelation(ctx context.Context, id string) error
+}
+
+// resourceIssueRelation manages a relation between two issues. Redmine cannot change relations, so every change
+// replaces the relation.
+func resourceIssueRelation() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceIssueRelationCreate,
+		ReadContext:   resourceIssueRelationRead,
+		DeleteContext: resourceIssueRelationDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceIssueRelationImport,
+		},
+		Timeouts:      resourceTimeoutsWithoutUpdate(),
+		CustomizeDiff: validateIssueRelationDelay,
+		Schema: map[string]*schema.Schema{
+			IssRelID: {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			IssRelIssueID: {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			IssRelIssueToID: {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			IssRelRelationType: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(issueRelationTypes, false),
+			},
+			IssRelDelay: {
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+// validateIssueRelationDelay rejects a delay for relation types other than precedes and follows. Redmine would
+// silently drop it which would lead to a diff after every apply.
+func validateIssueRelationDelay(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	relationType := d.Get(IssRelRelationType).(string)
+	if d.Get(IssRelDelay).(int) != 0 && relationType != "precedes" && relationType != "follows" {
+		return fmt.Errorf("%s can only be used with the relation types precedes and follows, not with %s",
+			IssRelDelay, relationType)
+	}
+
+	return nil
+}
+
+func resourceIssueRelationRead(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
+	relationID := d.Get(IssRelID).(string)
+
+	client := i.(IssueRelationClient)
+	relation, err := client.ReadIssueRelation(ctx, relationID)
+	if err != nil {
+		return handleReadError(d, "issue relation", err)
+	}
+
+	// Redmine stores relations like follows as their reverse with swapped issues. Keep the configured point of view so
+	// that the relation is not replaced over and over again.
+	configured := issueRelationFromState(d)
+	if reversed, ok := relation.Reversed(); ok && reversed.IssueID == configured.IssueID &&
+		reversed.IssueToID == configured.IssueToID && reversed.RelationType == configured.RelationType {
+		relation = reversed
+	}
+
+	log.Printf("issue relation read id %s, issue id %d", relation.ID, relation.IssueID)
+
+	return issueRelationSetToState(relation, d)
+}
+
+func resourceIssueRelationCreate(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := i.(IssueRelationClient)
+
+	relation := issueRelationFromState(d)
+
+	createdRelation, err := client.CreateIssueRelation(ctx, relation)
+	if err != nil {
+		return errorToDiags(err, resourceIssueRelation().Schema)
+	}
+
+	d.SetId(createdRelation.ID)
+
+	log.Printf("issue relation create id %s, issue id %d", createdRelation.ID, relation.IssueID)
+
+	diagRead := resourceIssueRelationRead(ctx, d, i)
+	diags = append(diags, diagRead...)
+
+	return diags
+}
+
+func resourceIssueRelationDelete(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := i.(IssueRelationClient)
+
+	relationID := d.Id()
+	err := client.DeleteIssueRelation(ctx, relationID)
+	// relations are deleted together with their issues
+	if err != nil && !redmine.IsNotFound(err) {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("issue relation delete id %s", relationID)
+
+	return diags
+}
+
+// resourceIssueRelationImport imports an issue relation by its numeric ID. Relations are imported from the point of
+// view that Redmine stores, f. i. a follows relation is imported as precedes relation of the other issue.
+func resourceIssueRelationImport(ctx context.Context, d *schema.ResourceData, i interface{}) ([]*schema.ResourceData, error) {
+	importID := d.Id()
+	if err := verifyNumericImportID("issue relation", importID); err != nil {
+		return nil, err
+	}
+
+	client := i.(IssueRelationClient)
+	relation, err := client.ReadIssueRelation(ctx, importID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not import issue relation '%s'", importID)
+	}
+
+	if err := diagsToError(issueRelationSetToState(relation, d)); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func issueRelationSetToState(relation *redmine.IssueRelation, d *schema.ResourceData) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	d.SetId(relation.ID)
+	if err := d.Set(IssRelID, relation.ID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(IssRelIssueID, relation.IssueID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(IssRelIssueToID, relation.IssueToID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(IssRelRelationType, relation.RelationType); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(IssRelDelay, relation.Delay); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func issueRelationFromState(d *schema.ResourceData) *redmine.IssueRelation {
+	relation := &redmine.IssueRelation{}
+	relation.IssueID = d.Get(IssRelIssueID).(int)
+	relation.IssueToID = d.Get(IssRelIssueToID).(int)
+	relation.RelationType = d.Get(IssRelRelationType).(string)
+	relation.Delay = d.Get(IssRelDelay).(int)
+
+	relationID := d.Id()
+	if relationID != "" && relationID != "0" {
+		relation.ID = relationID
+	}
+
+	return relation
+}
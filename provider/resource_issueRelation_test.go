@@ -0,0 +1,167 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/cloudogu/terraform-provider-redmine/redmine"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"regexp"
+	"testing"
+)
+
+const (
+	testIssueRelationTFResourceType = "redmine_issue_relation"
+	testIssueRelationTFResourceName = "test_issue_relation1"
+	testIssueRelationTFResource     = testIssueRelationTFResourceType + "." + testIssueRelationTFResourceName
+)
+
+const (
+	issRelKeyID           = "id"
+	issRelKeyIssueID      = "issue_id"
+	issRelKeyIssueToID    = "issue_to_id"
+	issRelKeyRelationType = "relation_type"
+	issRelKeyDelay        = "delay"
+)
+
+func TestAccIssueRelationCreate_blocks(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckIssueRelationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: issueRelationAsHCL("blocks", 0),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(testIssueRelationTFResource, issRelKeyID),
+					resource.TestCheckResourceAttrPair(testIssueRelationTFResource, issRelKeyIssueID, testIssueTFResourceType+".issue1", issKeyID),
+					resource.TestCheckResourceAttrPair(testIssueRelationTFResource, issRelKeyIssueToID, testIssueTFResourceType+".issue2", issKeyID),
+					resource.TestCheckResourceAttr(testIssueRelationTFResource, issRelKeyRelationType, "blocks"),
+					resource.TestCheckResourceAttr(testIssueRelationTFResource, issRelKeyDelay, "0"),
+				),
+			},
+			{
+				ResourceName:      testIssueRelationTFResource,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccIssueRelationCreate_followsWithDelay(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckIssueRelationDestroy,
+		Steps: []resource.TestStep{
+			{
+				// Redmine stores the relation as precedes relation of issue2 which must not lead to a diff
+				Config: issueRelationAsHCL("follows", 2),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair(testIssueRelationTFResource, issRelKeyIssueID, testIssueTFResourceType+".issue1", issKeyID),
+					resource.TestCheckResourceAttrPair(testIssueRelationTFResource, issRelKeyIssueToID, testIssueTFResourceType+".issue2", issKeyID),
+					resource.TestCheckResourceAttr(testIssueRelationTFResource, issRelKeyRelationType, "follows"),
+					resource.TestCheckResourceAttr(testIssueRelationTFResource, issRelKeyDelay, "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIssueRelationCreate_delayWithoutPrecedes(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckIssueRelationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      issueRelationAsHCL("relates", 2),
+				ExpectError: regexp.MustCompile("delay can only be used with the relation types precedes and follows"),
+			},
+		},
+	})
+}
+
+func testAccCheckIssueRelationDestroy(s *terraform.State) error {
+	cli := testAccProvider.Meta().(*redmine.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != testIssueRelationTFResourceType {
+			continue
+		}
+
+		// when
+		_, err := cli.ReadIssueRelation(context.Background(), rs.Primary.ID)
+
+		// then
+		if err == nil {
+			return fmt.Errorf("issue relation (%s) still exists", rs.Primary.ID)
+		}
+		if !redmine.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return testAccCheckIssueDestroy(s)
+}
+
+// issueRelationAsHCL renders a project with two issues and a relation from the first to the second issue.
+func issueRelationAsHCL(relationType string, delay int) string {
+	projectID := testProjectTFResource + ".id"
+	return basicProjectWithDescription("testproject", "project", "a project") + "\n" +
+		issueAsHCL("issue1", projectID, 2, "first issue", "", 2) + "\n" +
+		issueAsHCL("issue2", projectID, 2, "second issue", "", 2) + "\n" +
+		fmt.Sprintf(`resource "%s" "%s" {
+  issue_id = %s.issue1.id
+  issue_to_id = %s.issue2.id
+  relation_type = "%s"
+  delay = %d
+}`, testIssueRelationTFResourceType, testIssueRelationTFResourceName, testIssueTFResourceType, testIssueTFResourceType,
+			relationType, delay)
+}
+
+type fakeIssueRelationClient struct {
+	relation *redmine.IssueRelation
+}
+
+func (f *fakeIssueRelationClient) CreateIssueRelation(_ context.Context, _ *redmine.IssueRelation) (*redmine.IssueRelation, error) {
+	return f.relation, nil
+}
+
+func (f *fakeIssueRelationClient) ReadIssueRelation(_ context.Context, _ string) (*redmine.IssueRelation, error) {
+	return f.relation, nil
+}
+
+func (f *fakeIssueRelationClient) DeleteIssueRelation(_ context.Context, _ string) error {
+	return nil
+}
+
+func Test_resourceIssueRelationRead(t *testing.T) {
+	stored := &redmine.IssueRelation{ID: "7", IssueID: 4, IssueToID: 3, RelationType: "precedes", Delay: 2}
+	client := &fakeIssueRelationClient{relation: stored}
+
+	t.Run("should keep the configured point of view", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, resourceIssueRelation().Schema, map[string]interface{}{
+			IssRelIssueID: 3, IssRelIssueToID: 4, IssRelRelationType: "follows", IssRelDelay: 2,
+		})
+		d.SetId("7")
+
+		diags := resourceIssueRelationRead(context.Background(), d, client)
+
+		require.False(t, diags.HasError())
+		assert.Equal(t, &redmine.IssueRelation{ID: "7", IssueID: 3, IssueToID: 4, RelationType: "follows", Delay: 2},
+			issueRelationFromState(d))
+	})
+	t.Run("should take the stored relation if it was changed outside of Terraform", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, resourceIssueRelation().Schema, map[string]interface{}{
+			IssRelIssueID: 3, IssRelIssueToID: 4, IssRelRelationType: "blocks",
+		})
+		d.SetId("7")
+
+		diags := resourceIssueRelationRead(context.Background(), d, client)
+
+		require.False(t, diags.HasError())
+		assert.Equal(t, stored, issueRelationFromState(d))
+	})
+}
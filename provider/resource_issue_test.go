@@ -5,8 +5,11 @@ import (
 	"fmt"
 	"github.com/cloudogu/terraform-provider-redmine/redmine"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-	"strings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"regexp"
 	"testing"
 	"time"
 )
@@ -18,16 +21,26 @@ const (
 )
 
 const (
-	issKeyID            = "id"
-	issKeyProjectID     = "project_id"
-	issKeyTrackerID     = "tracker_id"
-	issKeySubject       = "subject"
-	issKeyDescription   = "description"
-	issKeyParentIssueID = "parent_issue_id"
-	issKeyPriorityID    = "priority_id"
-	issKeyCategoryID    = "category_id"
-	issKeyCreatedOn     = "created_on"
-	issKeyUpdatedOn     = "updated_on"
+	issKeyID             = "id"
+	issKeyProjectID      = "project_id"
+	issKeyTrackerID      = "tracker_id"
+	issKeySubject        = "subject"
+	issKeyDescription    = "description"
+	issKeyParentIssueID  = "parent_issue_id"
+	issKeyPriorityID     = "priority_id"
+	issKeyCategoryID     = "category_id"
+	issKeyAssignedToID   = "assigned_to_id"
+	issKeyStatusID       = "status_id"
+	issKeyStartDate      = "start_date"
+	issKeyDueDate        = "due_date"
+	issKeyDoneRatio      = "done_ratio"
+	issKeyEstimatedHours = "estimated_hours"
+	issKeyIsPrivate      = "is_private"
+	issKeyFixedVersionID = "fixed_version_id"
+	issKeyCreatedOn      = "created_on"
+	issKeyUpdatedOn      = "updated_on"
+	issKeyWatcherUserIDs = "watcher_user_ids"
+	issKeyJournals       = "journals"
 )
 
 func TestAccIssueCreate_basic(t *testing.T) {
@@ -301,6 +314,382 @@ func TestAccIssueCreate_addAndRemoveIssueCategory(t *testing.T) {
 	})
 }
 
+func TestAccIssueUpdate_planningFields(t *testing.T) {
+	projectResourceIDReference := testProjectTFResource + ".id"
+	// the assignee must be a member of the project
+	tfDependencyBlocks := projectMembershipAsHCL(prjMemKeyUserID, testUserTFResource, prjMemValueRoleDeveloper) + "\n" +
+		VersionAsHCL(testVersionTFResourceName, projectResourceIDReference, "Sprint 1", "first sprint", "open", "") + "\n"
+
+	plannedIssue := fmt.Sprintf(`resource "%s" "%s" {
+  project_id = %s
+  tracker_id = 2
+  subject = "issue subject"
+  assigned_to_id = %s.id
+  status_id = 2
+  start_date = "2021-06-01"
+  due_date = "2021-06-30"
+  done_ratio = 30
+  estimated_hours = 4.5
+  is_private = true
+  fixed_version_id = %s.id
+  depends_on = [%s]
+}`, testIssueTFResourceType, testIssueTFResourceName, projectResourceIDReference, testUserTFResource,
+		testVersionTFResource, testProjectMembershipTFResource)
+	unplannedIssue := fmt.Sprintf(`resource "%s" "%s" {
+  project_id = %s
+  tracker_id = 2
+  subject = "issue subject"
+  status_id = 2
+  start_date = "2021-06-01"
+  done_ratio = 0
+}`, testIssueTFResourceType, testIssueTFResourceName, projectResourceIDReference)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckIssueDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: tfDependencyBlocks + plannedIssue,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair(testIssueTFResource, issKeyAssignedToID, testUserTFResource, usrKeyID),
+					resource.TestCheckResourceAttr(testIssueTFResource, issKeyStatusID, "2"),
+					resource.TestCheckResourceAttr(testIssueTFResource, issKeyStartDate, "2021-06-01"),
+					resource.TestCheckResourceAttr(testIssueTFResource, issKeyDueDate, "2021-06-30"),
+					resource.TestCheckResourceAttr(testIssueTFResource, issKeyDoneRatio, "30"),
+					resource.TestCheckResourceAttr(testIssueTFResource, issKeyEstimatedHours, "4.5"),
+					resource.TestCheckResourceAttr(testIssueTFResource, issKeyIsPrivate, "true"),
+					resource.TestCheckResourceAttrPair(testIssueTFResource, issKeyFixedVersionID, testVersionTFResource, verKeyID),
+				),
+			},
+			{
+				Config: tfDependencyBlocks + unplannedIssue,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(testIssueTFResource, issKeyAssignedToID, "0"),
+					resource.TestCheckResourceAttr(testIssueTFResource, issKeyDueDate, ""),
+					resource.TestCheckResourceAttr(testIssueTFResource, issKeyDoneRatio, "0"),
+					resource.TestCheckResourceAttr(testIssueTFResource, issKeyEstimatedHours, "0"),
+					resource.TestCheckResourceAttr(testIssueTFResource, issKeyIsPrivate, "false"),
+					resource.TestCheckResourceAttr(testIssueTFResource, issKeyFixedVersionID, "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIssueUpdate_attachments(t *testing.T) {
+	projectResourceIDReference := testProjectTFResource + ".id"
+	issueWithAttachment := func(content, description string) string {
+		return basicProjectWithDescription("testproject", "project", "a project") + "\n" +
+			fmt.Sprintf(`resource "%s" "%s" {
+  project_id = %s
+  tracker_id = 2
+  subject = "issue subject"
+  attachment {
+    filename = "runbook.txt"
+    content = "%s"
+    description = "%s"
+  }
+}`, testIssueTFResourceType, testIssueTFResourceName, projectResourceIDReference, content, description)
+	}
+	// SHA256 checksums of the attachment contents
+	const firstHash = "616379a82174fec9fd0fbc534b74004c0e3d31e61a3d021b028b94529fb21ca1"
+	const secondHash = "234a353eb9812facaeeb538d40b0577857abe6e5b278b074a9ade15edbb22c22"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckIssueDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: issueWithAttachment("restart the service", "first version"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(testIssueTFResource, "attachment.#", "1"),
+					resource.TestCheckResourceAttr(testIssueTFResource, "attachment.0.content_hash", firstHash),
+				),
+			},
+			{
+				// only the description changes, so the file is not uploaded again
+				Config: issueWithAttachment("restart the service", "updated description"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(testIssueTFResource, "attachment.0.description", "updated description"),
+					resource.TestCheckResourceAttr(testIssueTFResource, "attachment.0.content_hash", firstHash),
+				),
+			},
+			{
+				Config: issueWithAttachment("restart the service twice", "updated description"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(testIssueTFResource, "attachment.#", "1"),
+					resource.TestCheckResourceAttr(testIssueTFResource, "attachment.0.content_hash", secondHash),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIssueUpdate_watchers(t *testing.T) {
+	projectResourceIDReference := testProjectTFResource + ".id"
+	issueWithWatchers := func(watcherUserIDs string) string {
+		return basicProjectWithDescription("testproject", "project", "a project") + "\n" +
+			userAsHCL(testUserTFResourceName, "jdoe", "Jane", "Doe", "jdoe@example.com", false, "active") + "\n" +
+			userAsHCL("lead", "jlead", "John", "Lead", "jlead@example.com", false, "active") + "\n" +
+			fmt.Sprintf(`resource "%s" "%s" {
+  project_id = %s
+  tracker_id = 2
+  subject = "incident"
+  watcher_user_ids = [%s]
+}`, testIssueTFResourceType, testIssueTFResourceName, projectResourceIDReference, watcherUserIDs)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckIssueDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: issueWithWatchers(testUserTFResource + ".id"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(testIssueTFResource, issKeyWatcherUserIDs+".#", "1"),
+					resource.TestCheckTypeSetElemAttrPair(testIssueTFResource, issKeyWatcherUserIDs+".*", testUserTFResource, usrKeyID),
+				),
+			},
+			{
+				Config: issueWithWatchers(testUserTFResourceType + ".lead.id"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(testIssueTFResource, issKeyWatcherUserIDs+".#", "1"),
+					resource.TestCheckTypeSetElemAttrPair(testIssueTFResource, issKeyWatcherUserIDs+".*", testUserTFResourceType+".lead", usrKeyID),
+				),
+			},
+			{
+				Config: issueWithWatchers(""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(testIssueTFResource, issKeyWatcherUserIDs+".#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIssueUpdate_updateNote(t *testing.T) {
+	projectResourceIDReference := testProjectTFResource + ".id"
+	issueWithNote := func(subject, note string) string {
+		return basicProjectWithDescription("testproject", "project", "a project") + "\n" +
+			fmt.Sprintf(`resource "%s" "%s" {
+  project_id = %s
+  tracker_id = 2
+  subject = "%s"
+  update_note = "%s"
+}`, testIssueTFResourceType, testIssueTFResourceName, projectResourceIDReference, subject, note)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckIssueDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: issueWithNote("issue subject", "created by Terraform"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					// the note is only added to updates
+					resource.TestCheckResourceAttr(testIssueTFResource, issKeyJournals+".#", "0"),
+				),
+			},
+			{
+				Config: issueWithNote("renamed subject", "renamed by the incident runbook"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(testIssueTFResource, issKeyJournals+".#", "1"),
+					resource.TestCheckResourceAttr(testIssueTFResource, issKeyJournals+".0.notes", "renamed by the incident runbook"),
+					resource.TestCheckResourceAttrSet(testIssueTFResource, issKeyJournals+".0.user_id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIssueUpdate_parentIssue(t *testing.T) {
+	projectResourceIDReference := testProjectTFResource + ".id"
+	issueWithParent := func(parentIssueID string) string {
+		return basicProjectWithDescription("testproject", "project", "a project") + "\n" +
+			issueAsHCL("parent1", projectResourceIDReference, 2, "first parent", "", 2) + "\n" +
+			issueAsHCL("parent2", projectResourceIDReference, 2, "second parent", "", 2) + "\n" +
+			fmt.Sprintf(`resource "%s" "%s" {
+  project_id = %s
+  tracker_id = 2
+  subject = "child"
+  parent_issue_id = %s
+}`, testIssueTFResourceType, testIssueTFResourceName, projectResourceIDReference, parentIssueID)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckIssueDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: issueWithParent("0"),
+				Check:  resource.TestCheckResourceAttr(testIssueTFResource, issKeyParentIssueID, "0"),
+			},
+			{
+				Config: issueWithParent(testIssueTFResourceType + ".parent1.id"),
+				Check:  resource.TestCheckResourceAttrPair(testIssueTFResource, issKeyParentIssueID, testIssueTFResourceType+".parent1", issKeyID),
+			},
+			{
+				Config: issueWithParent(testIssueTFResourceType + ".parent2.id"),
+				Check:  resource.TestCheckResourceAttrPair(testIssueTFResource, issKeyParentIssueID, testIssueTFResourceType+".parent2", issKeyID),
+			},
+			{
+				Config: issueWithParent("0"),
+				Check:  resource.TestCheckResourceAttr(testIssueTFResource, issKeyParentIssueID, "0"),
+			},
+		},
+	})
+}
+
+func Test_issueFromState(t *testing.T) {
+	t.Run("should read the parent issue", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, resourceIssue().Schema, map[string]interface{}{
+			IssProjectID:     1,
+			IssTrackerID:     2,
+			IssSubject:       "child",
+			IssParentIssueID: 7,
+		})
+
+		issue := issueFromState(d)
+
+		assert.Equal(t, 7, issue.ParentIssueID)
+	})
+	t.Run("should remove the parent issue if it is not configured", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, resourceIssue().Schema, map[string]interface{}{
+			IssProjectID: 1,
+			IssTrackerID: 2,
+			IssSubject:   "child",
+		})
+
+		issue := issueFromState(d)
+
+		assert.Equal(t, 0, issue.ParentIssueID)
+	})
+}
+
+func Test_resourceIssue_clearWatchers(t *testing.T) {
+	client := &fakeIssueClient{issue: &redmine.Issue{ID: "3", ProjectID: 1, TrackerID: 2, Subject: "incident"}}
+	sut := resourceIssue()
+	// Terraform treats an empty set like an unset attribute and keeps the state of computed attributes
+	require.False(t, sut.Schema[IssWatcherUserIDs].Computed)
+	issueConfig := func(watcherUserIDs []interface{}) *terraform.ResourceConfig {
+		return terraform.NewResourceConfigRaw(map[string]interface{}{
+			IssProjectID:      1,
+			IssTrackerID:      2,
+			IssSubject:        "incident",
+			IssWatcherUserIDs: watcherUserIDs,
+		})
+	}
+
+	diff, err := sut.Diff(context.Background(), nil, issueConfig([]interface{}{5}), client)
+	require.NoError(t, err)
+	state, diags := sut.Apply(context.Background(), nil, diff, client)
+	require.False(t, diags.HasError(), diags)
+	require.Equal(t, "1", state.Attributes[issKeyWatcherUserIDs+".#"])
+
+	diff, err = sut.Diff(context.Background(), state, issueConfig([]interface{}{}), client)
+	require.NoError(t, err)
+	require.NotNil(t, diff, "clearing the watchers must lead to a diff")
+	state, diags = sut.Apply(context.Background(), state, diff, client)
+
+	require.False(t, diags.HasError(), diags)
+	assert.Equal(t, []int{}, client.updated.WatcherUserIDs)
+	assert.Equal(t, "0", state.Attributes[issKeyWatcherUserIDs+".#"])
+}
+
+// fakeIssueClient stores a single issue and applies the watchers like Redmine.
+type fakeIssueClient struct {
+	IssueClient
+	AttachmentClient
+	issue   *redmine.Issue
+	updated *redmine.Issue
+}
+
+func (c *fakeIssueClient) CreateIssue(_ context.Context, issue *redmine.Issue) (*redmine.Issue, error) {
+	c.issue.WatcherUserIDs = issue.WatcherUserIDs
+	return c.issue, nil
+}
+
+func (c *fakeIssueClient) ReadIssue(_ context.Context, _ string) (*redmine.Issue, error) {
+	return c.issue, nil
+}
+
+func (c *fakeIssueClient) UpdateIssue(_ context.Context, issue *redmine.Issue) (*redmine.Issue, error) {
+	c.updated = issue
+	if issue.WatcherUserIDs != nil {
+		c.issue.WatcherUserIDs = issue.WatcherUserIDs
+	}
+	return c.issue, nil
+}
+
+func TestAccIssueCreate_invalidDueDate(t *testing.T) {
+	invalidIssue := fmt.Sprintf(`resource "%s" "%s" {
+  project_id = 1
+  tracker_id = 2
+  subject = "issue subject"
+  due_date = "30.06.2021"
+}`, testIssueTFResourceType, testIssueTFResourceName)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      invalidIssue,
+				ExpectError: regexp.MustCompile("invalid due date found"),
+			},
+		},
+	})
+}
+
+func TestAccIssueImport(t *testing.T) {
+	projectResourceIDReference := testProjectTFResource + ".id"
+	tfProjectAndIssueBlocks := basicProjectWithDescription("testproject", "project", "a project") + "\n" +
+		issueAsHCL(testIssueTFResourceName, projectResourceIDReference, 2, "issue subject", "This is an example issue", 2)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckIssueDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: tfProjectAndIssueBlocks,
+			},
+			{
+				ResourceName:      testIssueTFResource,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:  testIssueTFResource,
+				ImportState:   true,
+				ImportStateId: "not-a-number",
+				ExpectError:   regexp.MustCompile("expected a numeric ID"),
+			},
+		},
+	})
+}
+
+func TestAccIssueRead_deletedOutsideOfTerraform(t *testing.T) {
+	projectResourceIDReference := testProjectTFResource + ".id"
+	tfProjectAndIssueBlocks := basicProjectWithDescription("testproject", "project", "a project") + "\n" +
+		issueAsHCL(testIssueTFResourceName, projectResourceIDReference, 2, "issue subject", "This is an example issue", 2)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckIssueDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: tfProjectAndIssueBlocks,
+				Check: func(state *terraform.State) error {
+					issueID := state.RootModule().Resources[testIssueTFResource].Primary.ID
+					cli := testAccProvider.Meta().(*redmine.Client)
+					return cli.DeleteIssue(context.Background(), issueID)
+				},
+				// the issue is missing after the refresh so Terraform must plan to re-create it
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
 func testAccCheckIssueDestroy(s *terraform.State) error {
 	cli := testAccProvider.Meta().(*redmine.Client)
 
@@ -323,7 +712,7 @@ func testAccCheckIssueDestroy(s *terraform.State) error {
 			return nil
 		}
 
-		if !strings.Contains(err.Error(), "issue (id: "+issueID+") was not found") {
+		if !redmine.IsNotFound(err) {
 			return err
 		}
 	}
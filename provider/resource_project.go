@@ -6,19 +6,25 @@ import (
 	"github.com/cloudogu/terraform-provider-redmine/redmine"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+	"strconv"
 )
 
 const (
-	PrjID             = "id"
-	PrjName           = "name"
-	PrjIdentifier     = "identifier"
-	PrjDescription    = "description"
-	PrjHomepage       = "homepage"
-	PrjIsPublic       = "is_public"
-	PrjParentID       = "parent_id"
-	PrjInheritMembers = "inherit_members"
-	PrjCreatedOn      = "created_on"
-	PrjUpdatedOn      = "updated_on"
+	PrjID                  = "id"
+	PrjName                = "name"
+	PrjIdentifier          = "identifier"
+	PrjDescription         = "description"
+	PrjHomepage            = "homepage"
+	PrjIsPublic            = "is_public"
+	PrjParentID            = "parent_id"
+	PrjInheritMembers      = "inherit_members"
+	PrjCreatedOn           = "created_on"
+	PrjUpdatedOn           = "updated_on"
+	PrjCustomField         = "custom_field"
+	PrjEnabledModuleNames  = "enabled_module_names"
+	PrjTrackerIDs          = "tracker_ids"
+	PrjIssueCustomFieldIDs = "issue_custom_field_ids"
 )
 
 // ProjectClient provides methods for reading and modifying Redmine projects.
@@ -27,6 +33,8 @@ type ProjectClient interface {
 	CreateProject(ctx context.Context, project *redmine.Project) (*redmine.Project, error)
 	// ReadProject reads a project identified by the id. The id must not be empty string or "0".
 	ReadProject(ctx context.Context, id string) (*redmine.Project, error)
+	// ReadProjectByIdentifier reads a project identified by its identifier. The identifier must not be empty.
+	ReadProjectByIdentifier(ctx context.Context, identifier string) (*redmine.Project, error)
 	// UpdateProject updates an existing project.
 	UpdateProject(ctx context.Context, project *redmine.Project) (*redmine.Project, error)
 	// DeleteProject deletes a project identified by the id. The id must not be empty string or "0".
@@ -39,6 +47,10 @@ func resourceProject() *schema.Resource {
 		ReadContext:   resourceProjectRead,
 		UpdateContext: resourceProjectUpdate,
 		DeleteContext: resourceProjectDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceProjectImport,
+		},
+		Timeouts: resourceTimeouts(),
 		Schema: map[string]*schema.Schema{
 			PrjID: {
 				Type:     schema.TypeString,
@@ -86,6 +98,27 @@ func resourceProject() *schema.Resource {
 				Optional: true,
 				Computed: true,
 			},
+			PrjCustomField: customFieldSchema(),
+			// the associations are only managed if they are configured, otherwise Redmine's defaults are kept. Module
+			// names are not validated because plugins may add modules to the default ones like issue_tracking or wiki.
+			PrjEnabledModuleNames: {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			PrjTrackerIDs: {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+			PrjIssueCustomFieldIDs: {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
 		},
 	}
 }
@@ -96,7 +129,7 @@ func resourceProjectRead(ctx context.Context, d *schema.ResourceData, i interfac
 	client := i.(ProjectClient)
 	project, err := client.ReadProject(ctx, projectID)
 	if err != nil {
-		return diag.FromErr(err)
+		return handleReadError(d, "project", err)
 	}
 
 	return projectSetToState(project, d)
@@ -107,10 +140,19 @@ func resourceProjectCreate(ctx context.Context, d *schema.ResourceData, i interf
 	client := i.(ProjectClient)
 
 	project := projectFromState(d)
+	if _, ok := d.GetOk(PrjEnabledModuleNames); !ok {
+		project.EnabledModuleNames = nil
+	}
+	if _, ok := d.GetOk(PrjTrackerIDs); !ok {
+		project.TrackerIDs = nil
+	}
+	if _, ok := d.GetOk(PrjIssueCustomFieldIDs); !ok {
+		project.IssueCustomFieldIDs = nil
+	}
 
 	createdProject, err := client.CreateProject(ctx, project)
 	if err != nil {
-		return diag.FromErr(err)
+		return errorToDiags(err, resourceProject().Schema)
 	}
 
 	d.SetId(createdProject.ID)
@@ -126,6 +168,15 @@ func resourceProjectUpdate(ctx context.Context, d *schema.ResourceData, i interf
 	client := i.(ProjectClient)
 
 	project := projectFromState(d)
+	if !d.HasChange(PrjEnabledModuleNames) {
+		project.EnabledModuleNames = nil
+	}
+	if !d.HasChange(PrjTrackerIDs) {
+		project.TrackerIDs = nil
+	}
+	if !d.HasChange(PrjIssueCustomFieldIDs) {
+		project.IssueCustomFieldIDs = nil
+	}
 
 	if d.HasChange(PrjIdentifier) {
 		oldIdentifierRaw, newIdentifierRaw := d.GetChange(PrjIdentifier)
@@ -142,7 +193,7 @@ func resourceProjectUpdate(ctx context.Context, d *schema.ResourceData, i interf
 
 	_, err := client.UpdateProject(ctx, project)
 	if err != nil {
-		return diag.FromErr(err)
+		return errorToDiags(err, resourceProject().Schema)
 	}
 
 	diagRead := resourceProjectRead(ctx, d, i)
@@ -164,6 +215,29 @@ func resourceProjectDelete(ctx context.Context, d *schema.ResourceData, i interf
 	return diags
 }
 
+// resourceProjectImport imports a project either by its numeric ID or by its identifier.
+func resourceProjectImport(ctx context.Context, d *schema.ResourceData, i interface{}) ([]*schema.ResourceData, error) {
+	client := i.(ProjectClient)
+	importID := d.Id()
+
+	var project *redmine.Project
+	var err error
+	if _, convErr := strconv.Atoi(importID); convErr == nil {
+		project, err = client.ReadProject(ctx, importID)
+	} else {
+		project, err = client.ReadProjectByIdentifier(ctx, importID)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not import project '%s'", importID)
+	}
+
+	if err := diagsToError(projectSetToState(project, d)); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
 func projectSetToState(project *redmine.Project, d *schema.ResourceData) diag.Diagnostics {
 	d.SetId(project.ID)
 	if err := d.Set(PrjName, project.Name); err != nil {
@@ -175,6 +249,9 @@ func projectSetToState(project *redmine.Project, d *schema.ResourceData) diag.Di
 	if err := d.Set(PrjDescription, project.Description); err != nil {
 		return diag.FromErr(err)
 	}
+	if err := d.Set(PrjHomepage, project.Homepage); err != nil {
+		return diag.FromErr(err)
+	}
 	if err := d.Set(PrjIsPublic, project.IsPublic); err != nil {
 		return diag.FromErr(err)
 	}
@@ -190,7 +267,16 @@ func projectSetToState(project *redmine.Project, d *schema.ResourceData) diag.Di
 	if err := d.Set(PrjUpdatedOn, project.UpdatedOn); err != nil {
 		return diag.FromErr(err)
 	}
-	return nil
+	if err := d.Set(PrjEnabledModuleNames, project.EnabledModuleNames); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(PrjTrackerIDs, project.TrackerIDs); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(PrjIssueCustomFieldIDs, project.IssueCustomFieldIDs); err != nil {
+		return diag.FromErr(err)
+	}
+	return customFieldsSetToState(project.CustomFields, PrjCustomField, d)
 }
 
 func projectFromState(d *schema.ResourceData) *redmine.Project {
@@ -205,6 +291,20 @@ func projectFromState(d *schema.ResourceData) *redmine.Project {
 	project.InheritMembers = d.Get(PrjInheritMembers).(bool)
 	project.CreatedOn = d.Get(PrjCreatedOn).(string)
 	project.UpdatedOn = d.Get(PrjUpdatedOn).(string)
+	project.CustomFields = customFieldsFromState(d, PrjCustomField)
+
+	project.EnabledModuleNames = []string{}
+	for _, moduleName := range d.Get(PrjEnabledModuleNames).(*schema.Set).List() {
+		project.EnabledModuleNames = append(project.EnabledModuleNames, moduleName.(string))
+	}
+	project.TrackerIDs = []int{}
+	for _, trackerID := range d.Get(PrjTrackerIDs).(*schema.Set).List() {
+		project.TrackerIDs = append(project.TrackerIDs, trackerID.(int))
+	}
+	project.IssueCustomFieldIDs = []int{}
+	for _, customFieldID := range d.Get(PrjIssueCustomFieldIDs).(*schema.Set).List() {
+		project.IssueCustomFieldIDs = append(project.IssueCustomFieldIDs, customFieldID.(int))
+	}
 
 	return project
 }
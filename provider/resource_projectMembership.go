@@ -0,0 +1,263 @@
+package provider
+
+import (
+	"context"
+	"github.com/cloudogu/terraform-provider-redmine/redmine"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+	"log"
+	"strconv"
+)
+
+const (
+	PrjMemID        = "id"
+	PrjMemProjectID = "project_id"
+	PrjMemUserID    = "user_id"
+	PrjMemGroupID   = "group_id"
+	PrjMemRoleIDs   = "role_ids"
+)
+
+// ProjectMembershipClient provides methods for reading and modifying the memberships of Redmine projects.
+type ProjectMembershipClient interface {
+	// CreateMembership creates a membership of a user or group in a project.
+	CreateMembership(ctx context.Context, membership *redmine.Membership) (*redmine.Membership, error)
+	// ReadMembership reads a membership identified by the id. The id must not be empty string or "0".
+	ReadMembership(ctx context.Context, id string) (*redmine.Membership, error)
+	// ReadProjectMemberships reads all memberships of a project identified by the id including inherited ones.
+	ReadProjectMemberships(ctx context.Context, projectID string) ([]*redmine.Membership, error)
+	// UpdateMembership updates the directly assigned roles of an existing membership.
+	UpdateMembership(ctx context.Context, membership *redmine.Membership) (*redmine.Membership, error)
+	// DeleteMembership deletes a membership identified by the id. The id must not be empty string or "0".
+	DeleteMembership(ctx context.Context, id string) error
+}
+
+// resourceProjectMembership manages the roles that a user or group has in a project. Roles that are inherited from a
+// parent project (inherit_members) or from a group are not part of role_ids and never lead to a diff.
+func resourceProjectMembership() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceProjectMembershipCreate,
+		ReadContext:   resourceProjectMembershipRead,
+		UpdateContext: resourceProjectMembershipUpdate,
+		DeleteContext: resourceProjectMembershipDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceProjectMembershipImport,
+		},
+		Timeouts: resourceTimeouts(),
+		Schema: map[string]*schema.Schema{
+			PrjMemID: {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			PrjMemProjectID: {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			PrjMemUserID: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{PrjMemUserID, PrjMemGroupID},
+			},
+			PrjMemGroupID: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{PrjMemUserID, PrjMemGroupID},
+			},
+			PrjMemRoleIDs: {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+		},
+	}
+}
+
+func resourceProjectMembershipRead(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
+	membershipID := d.Get(PrjMemID).(string)
+
+	client := i.(ProjectMembershipClient)
+	membership, err := client.ReadMembership(ctx, membershipID)
+	if err != nil {
+		return handleReadError(d, "project membership", err)
+	}
+
+	// a membership that only consists of inherited roles was not created by Terraform or its roles were removed
+	// outside of Terraform
+	if len(membership.RoleIDs) == 0 && !d.IsNewResource() {
+		log.Printf("[WARN] project membership (id: %s) has no directly assigned roles, removing it from state", membershipID)
+		d.SetId("")
+		return nil
+	}
+
+	log.Printf("project membership read id %s, project id %d", membership.ID, membership.ProjectID)
+
+	return projectMembershipSetToState(membership, d)
+}
+
+func resourceProjectMembershipCreate(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := i.(ProjectMembershipClient)
+
+	membership := projectMembershipFromState(d)
+
+	createdMembership, err := client.CreateMembership(ctx, membership)
+	if err != nil {
+		createdMembership, err = adoptInheritedMembership(ctx, client, membership, err)
+		if err != nil {
+			return errorToDiags(err, resourceProjectMembership().Schema)
+		}
+	}
+
+	d.SetId(createdMembership.ID)
+
+	log.Printf("project membership create id %s, project id %d", createdMembership.ID, membership.ProjectID)
+
+	diagRead := resourceProjectMembershipRead(ctx, d, i)
+	diags = append(diags, diagRead...)
+
+	return diags
+}
+
+// adoptInheritedMembership assigns the roles to an existing membership of the same user or group if the membership
+// could not be created because the user or group already inherited roles in the project. Redmine keeps exactly one
+// membership per user or group and project, so inherited roles and directly assigned roles share a membership.
+// createErr is returned unchanged if there is no such membership.
+func adoptInheritedMembership(ctx context.Context, client ProjectMembershipClient, membership *redmine.Membership,
+	createErr error) (*redmine.Membership, error) {
+	var validationErr *redmine.ValidationError
+	if !errors.As(createErr, &validationErr) {
+		return nil, createErr
+	}
+
+	memberships, err := client.ReadProjectMemberships(ctx, strconv.Itoa(membership.ProjectID))
+	if err != nil {
+		return nil, createErr
+	}
+
+	for _, existing := range memberships {
+		if existing.UserID != membership.UserID || existing.GroupID != membership.GroupID {
+			continue
+		}
+		// memberships with directly assigned roles are managed elsewhere and must not be taken over
+		if len(existing.RoleIDs) > 0 {
+			return nil, createErr
+		}
+
+		log.Printf("project membership id %s only contains inherited roles, assigning roles to it", existing.ID)
+		existing.RoleIDs = membership.RoleIDs
+		return client.UpdateMembership(ctx, existing)
+	}
+
+	return nil, createErr
+}
+
+func resourceProjectMembershipUpdate(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := i.(ProjectMembershipClient)
+
+	membership := projectMembershipFromState(d)
+
+	_, err := client.UpdateMembership(ctx, membership)
+	if err != nil {
+		return errorToDiags(err, resourceProjectMembership().Schema)
+	}
+
+	log.Printf("project membership update id %s", membership.ID)
+
+	diagRead := resourceProjectMembershipRead(ctx, d, i)
+	diags = append(diags, diagRead...)
+
+	return diags
+}
+
+func resourceProjectMembershipDelete(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := i.(ProjectMembershipClient)
+
+	membershipID := d.Id()
+	membership, err := client.ReadMembership(ctx, membershipID)
+	if redmine.IsNotFound(err) {
+		return diags
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Redmine refuses to delete memberships with inherited roles. Removing the directly assigned roles leaves the
+	// membership as it was before Terraform assigned roles to it.
+	if len(membership.InheritedRoleIDs) > 0 {
+		membership.RoleIDs = []int{}
+		_, err = client.UpdateMembership(ctx, membership)
+	} else {
+		err = client.DeleteMembership(ctx, membershipID)
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("project membership delete id %s", membershipID)
+
+	return diags
+}
+
+// resourceProjectMembershipImport imports a project membership by its numeric ID.
+func resourceProjectMembershipImport(ctx context.Context, d *schema.ResourceData, i interface{}) ([]*schema.ResourceData, error) {
+	importID := d.Id()
+	if err := verifyNumericImportID("project membership", importID); err != nil {
+		return nil, err
+	}
+
+	client := i.(ProjectMembershipClient)
+	membership, err := client.ReadMembership(ctx, importID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not import project membership '%s'", importID)
+	}
+
+	if err := diagsToError(projectMembershipSetToState(membership, d)); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func projectMembershipSetToState(membership *redmine.Membership, d *schema.ResourceData) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	d.SetId(membership.ID)
+	if err := d.Set(PrjMemProjectID, membership.ProjectID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(PrjMemUserID, membership.UserID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(PrjMemGroupID, membership.GroupID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(PrjMemRoleIDs, membership.RoleIDs); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func projectMembershipFromState(d *schema.ResourceData) *redmine.Membership {
+	membership := &redmine.Membership{}
+	membership.ProjectID = d.Get(PrjMemProjectID).(int)
+	membership.UserID = d.Get(PrjMemUserID).(int)
+	membership.GroupID = d.Get(PrjMemGroupID).(int)
+
+	for _, roleID := range d.Get(PrjMemRoleIDs).(*schema.Set).List() {
+		membership.RoleIDs = append(membership.RoleIDs, roleID.(int))
+	}
+
+	membershipID := d.Id()
+	if membershipID != "" && membershipID != "0" {
+		membership.ID = membershipID
+	}
+
+	return membership
+}
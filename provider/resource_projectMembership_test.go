@@ -0,0 +1,195 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/cloudogu/terraform-provider-redmine/redmine"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+const (
+	testProjectMembershipTFResourceType = "redmine_project_membership"
+	testProjectMembershipTFResourceName = "test_project_membership1"
+	testProjectMembershipTFResource     = testProjectMembershipTFResourceType + "." + testProjectMembershipTFResourceName
+)
+
+const (
+	// these role IDs are part of Redmine's default configuration
+	prjMemValueRoleManager   = 3
+	prjMemValueRoleDeveloper = 4
+
+	prjMemKeyID        = "id"
+	prjMemKeyProjectID = "project_id"
+	prjMemKeyUserID    = "user_id"
+	prjMemKeyGroupID   = "group_id"
+	prjMemKeyRoleIDs   = "role_ids"
+)
+
+func TestAccProjectMembershipCreate_user(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckProjectMembershipDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: projectMembershipAsHCL(prjMemKeyUserID, testUserTFResource, prjMemValueRoleDeveloper),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(testProjectMembershipTFResource, prjMemKeyID),
+					resource.TestCheckResourceAttrPair(testProjectMembershipTFResource, prjMemKeyProjectID, testProjectTFResource, prjKeyID),
+					resource.TestCheckResourceAttrPair(testProjectMembershipTFResource, prjMemKeyUserID, testUserTFResource, usrKeyID),
+					resource.TestCheckResourceAttr(testProjectMembershipTFResource, prjMemKeyGroupID, "0"),
+					resource.TestCheckResourceAttr(testProjectMembershipTFResource, prjMemKeyRoleIDs+".#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccProjectMembershipUpdate_rolesChanged(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckProjectMembershipDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: projectMembershipAsHCL(prjMemKeyGroupID, testGroupTFResource, prjMemValueRoleDeveloper),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair(testProjectMembershipTFResource, prjMemKeyGroupID, testGroupTFResource, grpKeyID),
+					resource.TestCheckResourceAttr(testProjectMembershipTFResource, prjMemKeyRoleIDs+".#", "1"),
+				),
+			},
+			{
+				Config: projectMembershipAsHCL(prjMemKeyGroupID, testGroupTFResource, prjMemValueRoleDeveloper, prjMemValueRoleManager),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(testProjectMembershipTFResource, prjMemKeyRoleIDs+".#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccProjectMembershipImport(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckProjectMembershipDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: projectMembershipAsHCL(prjMemKeyUserID, testUserTFResource, prjMemValueRoleDeveloper),
+			},
+			{
+				ResourceName:      testProjectMembershipTFResource,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func Test_adoptInheritedMembership(t *testing.T) {
+	createErr := &redmine.ValidationError{APIError: redmine.APIError{StatusCode: 422, Messages: []string{"User has already been taken"}}}
+
+	t.Run("should assign roles to membership with only inherited roles", func(t *testing.T) {
+		client := &fakeProjectMembershipClient{memberships: []*redmine.Membership{
+			{ID: "7", ProjectID: 2, UserID: 5, InheritedRoleIDs: []int{3}},
+		}}
+
+		membership, err := adoptInheritedMembership(context.Background(), client,
+			&redmine.Membership{ProjectID: 2, UserID: 5, RoleIDs: []int{4}}, createErr)
+
+		require.NoError(t, err)
+		assert.Equal(t, "7", membership.ID)
+		require.Len(t, client.updated, 1)
+		assert.Equal(t, []int{4}, client.updated[0].RoleIDs)
+	})
+	t.Run("should not take over membership with directly assigned roles", func(t *testing.T) {
+		client := &fakeProjectMembershipClient{memberships: []*redmine.Membership{
+			{ID: "7", ProjectID: 2, UserID: 5, RoleIDs: []int{3}},
+		}}
+
+		_, err := adoptInheritedMembership(context.Background(), client,
+			&redmine.Membership{ProjectID: 2, UserID: 5, RoleIDs: []int{4}}, createErr)
+
+		assert.Equal(t, createErr, err)
+		assert.Empty(t, client.updated)
+	})
+	t.Run("should not take over membership of another principal", func(t *testing.T) {
+		client := &fakeProjectMembershipClient{memberships: []*redmine.Membership{
+			{ID: "7", ProjectID: 2, GroupID: 5, InheritedRoleIDs: []int{3}},
+		}}
+
+		_, err := adoptInheritedMembership(context.Background(), client,
+			&redmine.Membership{ProjectID: 2, UserID: 5, RoleIDs: []int{4}}, createErr)
+
+		assert.Equal(t, createErr, err)
+		assert.Empty(t, client.updated)
+	})
+}
+
+type fakeProjectMembershipClient struct {
+	ProjectMembershipClient
+	memberships []*redmine.Membership
+	updated     []*redmine.Membership
+}
+
+func (c *fakeProjectMembershipClient) ReadProjectMemberships(_ context.Context, _ string) ([]*redmine.Membership, error) {
+	return c.memberships, nil
+}
+
+func (c *fakeProjectMembershipClient) UpdateMembership(_ context.Context, membership *redmine.Membership) (*redmine.Membership, error) {
+	c.updated = append(c.updated, membership)
+	return membership, nil
+}
+
+func testAccCheckProjectMembershipDestroy(s *terraform.State) error {
+	cli := testAccProvider.Meta().(*redmine.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != testProjectMembershipTFResourceType {
+			continue
+		}
+
+		membershipID := rs.Primary.ID
+
+		// when
+		membership, err := cli.ReadMembership(context.Background(), membershipID)
+
+		// then
+		if err == nil {
+			if len(membership.RoleIDs) > 0 {
+				return fmt.Errorf("project membership (%s) still exists", rs.Primary.ID)
+			}
+
+			continue
+		}
+
+		if !redmine.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// projectMembershipAsHCL renders a project, a user, a group and a membership of either the user or the group in the
+// project. principalKey must be user_id or group_id.
+func projectMembershipAsHCL(principalKey, principalResource string, roleIDs ...int) string {
+	roleIDList := ""
+	for i, roleID := range roleIDs {
+		if i > 0 {
+			roleIDList += ", "
+		}
+		roleIDList += fmt.Sprint(roleID)
+	}
+
+	return basicProjectWithDescription("testproject", "project", "a project") + "\n" +
+		userAsHCL(testUserTFResourceName, "jdoe", "Jane", "Doe", "jdoe@example.com", false, "active") + "\n" +
+		groupAsHCL(testGroupTFResourceName, "developers", "") + "\n" +
+		fmt.Sprintf(`resource "%s" "%s" {
+  project_id = %s.id
+  %s = %s.id
+  role_ids = [%s]
+}`, testProjectMembershipTFResourceType, testProjectMembershipTFResourceName, testProjectTFResource,
+			principalKey, principalResource, roleIDList)
+}
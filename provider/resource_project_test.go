@@ -8,7 +8,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"strings"
+	"regexp"
 	"testing"
 	"time"
 )
@@ -34,6 +34,9 @@ const (
 	prjKeyInheritMembers = "inherit_members"
 	prjKeyCreatedOn      = "created_on"
 	prjKeyUpdatedOn      = "updated_on"
+
+	prjKeyEnabledModuleNames = "enabled_module_names"
+	prjKeyTrackerIDs         = "tracker_ids"
 )
 
 func TestAccProjectCreate_basic(t *testing.T) {
@@ -185,6 +188,75 @@ func TestAccProjectUpdate(t *testing.T) {
 	})
 }
 
+func TestAccProjectUpdate_modulesAndTrackers(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: projectWithModulesAsHCL(`"issue_tracking", "wiki"`, "1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(testProjectTFResource, prjKeyEnabledModuleNames+".#", "2"),
+					resource.TestCheckTypeSetElemAttr(testProjectTFResource, prjKeyEnabledModuleNames+".*", "issue_tracking"),
+					resource.TestCheckTypeSetElemAttr(testProjectTFResource, prjKeyEnabledModuleNames+".*", "wiki"),
+					resource.TestCheckResourceAttr(testProjectTFResource, prjKeyTrackerIDs+".#", "1"),
+					resource.TestCheckTypeSetElemAttr(testProjectTFResource, prjKeyTrackerIDs+".*", "1"),
+				),
+			},
+			{
+				Config: projectWithModulesAsHCL(`"issue_tracking", "time_tracking"`, "1, 2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(testProjectTFResource, prjKeyEnabledModuleNames+".#", "2"),
+					resource.TestCheckTypeSetElemAttr(testProjectTFResource, prjKeyEnabledModuleNames+".*", "issue_tracking"),
+					resource.TestCheckTypeSetElemAttr(testProjectTFResource, prjKeyEnabledModuleNames+".*", "time_tracking"),
+					resource.TestCheckResourceAttr(testProjectTFResource, prjKeyTrackerIDs+".#", "2"),
+					resource.TestCheckTypeSetElemAttr(testProjectTFResource, prjKeyTrackerIDs+".*", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccProjectCreate_duplicateIdentifier(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: basicProjectWithDescription(prjValueIdentifier, prjValueName, "This is an example project") + "\n" +
+					genericProjectAsHCL("project2", prjValueIdentifier, "Another project", "Yet another project",
+						"https://www.example.com/", true, false),
+				ExpectError: regexp.MustCompile("Identifier has already been taken"),
+			},
+		},
+	})
+}
+
+func TestAccProjectImport(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: basicProjectWithDescription(prjValueIdentifier, prjValueName, "This is an example project"),
+			},
+			{
+				// import by numeric ID
+				ResourceName:      testProjectTFResource,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// import by project identifier
+				ResourceName:      testProjectTFResource,
+				ImportState:       true,
+				ImportStateId:     prjValueIdentifier,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
 func testAccCheckProjectDestroy(s *terraform.State) error {
 	cli := testAccProvider.Meta().(*redmine.Client)
 
@@ -207,7 +279,7 @@ func testAccCheckProjectDestroy(s *terraform.State) error {
 			return nil
 		}
 
-		if !strings.Contains(err.Error(), "project (id: "+projectID+") was not found") {
+		if !redmine.IsNotFound(err) {
 			return err
 		}
 	}
@@ -234,6 +306,15 @@ func genericProjectAsHCL(tfName, identifier, name, description, homepage string,
 		identifier, name, description, homepage, isPublic, inheritMembers)
 }
 
+func projectWithModulesAsHCL(moduleNames, trackerIDs string) string {
+	return fmt.Sprintf(`resource "%s" "%s" {
+  identifier = "%s"
+  name = "%s"
+  enabled_module_names = [%s]
+  tracker_ids = [%s]
+}`, testProjectTFResourceType, testProjectTFResourceName, prjValueIdentifier, prjValueName, moduleNames, trackerIDs)
+}
+
 func assertNotEqual(resourceField string, expected, actual interface{}) error {
 	if assert.ObjectsAreEqual(expected, actual) {
 		return fmt.Errorf("field value %s expected to be not equal:\n"+
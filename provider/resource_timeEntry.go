@@ -0,0 +1,271 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/cloudogu/terraform-provider-redmine/redmine"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/pkg/errors"
+	"log"
+)
+
+const (
+	TimEntID          = "id"
+	TimEntProjectID   = "project_id"
+	TimEntIssueID     = "issue_id"
+	TimEntUserID      = "user_id"
+	TimEntSpentOn     = "spent_on"
+	TimEntHours       = "hours"
+	TimEntActivityID  = "activity_id"
+	TimEntComments    = "comments"
+	TimEntCreatedOn   = "created_on"
+	TimEntUpdatedOn   = "updated_on"
+	TimEntCustomField = "custom_field"
+)
+
+// TimeEntryClient provides methods for reading and modifying Redmine time entries.
+type TimeEntryClient interface {
+	// CreateTimeEntry creates a time entry.
+	CreateTimeEntry(ctx context.Context, timeEntry *redmine.TimeEntry) (*redmine.TimeEntry, error)
+	// ReadTimeEntry reads a time entry identified by the id. The id must not be empty string or "0".
+	ReadTimeEntry(ctx context.Context, id string) (*redmine.TimeEntry, error)
+	// UpdateTimeEntry updates an existing time entry.
+	UpdateTimeEntry(ctx context.Context, timeEntry *redmine.TimeEntry) (*redmine.TimeEntry, error)
+	// DeleteTimeEntry deletes a time entry identified by the id. The id must not be empty string or "0".
+	DeleteTimeEntry(ctx context.Context, id string) error
+}
+
+// resourceTimeEntry manages the hours spent on a project or an issue. The project is derived from the issue if only
+// issue_id is configured.
+func resourceTimeEntry() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceTimeEntryCreate,
+		ReadContext:   resourceTimeEntryRead,
+		UpdateContext: resourceTimeEntryUpdate,
+		DeleteContext: resourceTimeEntryDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceTimeEntryImport,
+		},
+		Timeouts: resourceTimeouts(),
+		Schema: map[string]*schema.Schema{
+			TimEntID: {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			TimEntProjectID: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				AtLeastOneOf: []string{TimEntProjectID, TimEntIssueID},
+			},
+			TimEntIssueID: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				AtLeastOneOf: []string{TimEntProjectID, TimEntIssueID},
+			},
+			// Redmine books the time for the authenticated user if user_id is not configured
+			TimEntUserID: {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			// Redmine uses the current date if spent_on is not configured
+			TimEntSpentOn: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringMatch(dueDateYYYYMMDDRegexp, "invalid date found; expected formatted date (YYYY-MM-DD)"),
+			},
+			TimEntHours: {
+				Type:         schema.TypeFloat,
+				Required:     true,
+				ValidateFunc: validatePositiveHours,
+			},
+			// Redmine uses the default time entry activity if activity_id is not configured
+			TimEntActivityID: {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			TimEntComments: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringLenBetween(0, 1024),
+			},
+			TimEntCreatedOn: {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			TimEntUpdatedOn: {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			TimEntCustomField: customFieldSchema(),
+		},
+	}
+}
+
+func resourceTimeEntryRead(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
+	timeEntryID := d.Get(TimEntID).(string)
+
+	client := i.(TimeEntryClient)
+	timeEntry, err := client.ReadTimeEntry(ctx, timeEntryID)
+	if err != nil {
+		return handleReadError(d, "time entry", err)
+	}
+
+	log.Printf("time entry read id %s, project id %d", timeEntry.ID, timeEntry.ProjectID)
+
+	return timeEntrySetToState(timeEntry, d)
+}
+
+func resourceTimeEntryCreate(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := i.(TimeEntryClient)
+
+	timeEntry := timeEntryFromState(d)
+
+	createdTimeEntry, err := client.CreateTimeEntry(ctx, timeEntry)
+	if err != nil {
+		return errorToDiags(err, resourceTimeEntry().Schema)
+	}
+
+	d.SetId(createdTimeEntry.ID)
+
+	log.Printf("time entry create id %s, project id %d", createdTimeEntry.ID, createdTimeEntry.ProjectID)
+
+	diagRead := resourceTimeEntryRead(ctx, d, i)
+	diags = append(diags, diagRead...)
+
+	return diags
+}
+
+func resourceTimeEntryUpdate(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := i.(TimeEntryClient)
+
+	timeEntry := timeEntryFromState(d)
+
+	_, err := client.UpdateTimeEntry(ctx, timeEntry)
+	if err != nil {
+		return errorToDiags(err, resourceTimeEntry().Schema)
+	}
+
+	log.Printf("time entry update id %s", timeEntry.ID)
+
+	diagRead := resourceTimeEntryRead(ctx, d, i)
+	diags = append(diags, diagRead...)
+
+	return diags
+}
+
+func resourceTimeEntryDelete(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := i.(TimeEntryClient)
+
+	timeEntryID := d.Id()
+	err := client.DeleteTimeEntry(ctx, timeEntryID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("time entry delete id %s", timeEntryID)
+
+	return diags
+}
+
+// resourceTimeEntryImport imports a time entry by its numeric ID.
+func resourceTimeEntryImport(ctx context.Context, d *schema.ResourceData, i interface{}) ([]*schema.ResourceData, error) {
+	importID := d.Id()
+	if err := verifyNumericImportID("time entry", importID); err != nil {
+		return nil, err
+	}
+
+	client := i.(TimeEntryClient)
+	timeEntry, err := client.ReadTimeEntry(ctx, importID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not import time entry '%s'", importID)
+	}
+
+	if err := diagsToError(timeEntrySetToState(timeEntry, d)); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func timeEntrySetToState(timeEntry *redmine.TimeEntry, d *schema.ResourceData) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	d.SetId(timeEntry.ID)
+	if err := d.Set(TimEntID, timeEntry.ID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(TimEntProjectID, timeEntry.ProjectID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(TimEntIssueID, timeEntry.IssueID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(TimEntUserID, timeEntry.UserID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(TimEntSpentOn, timeEntry.SpentOn); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(TimEntHours, timeEntry.Hours); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(TimEntActivityID, timeEntry.ActivityID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(TimEntComments, timeEntry.Comments); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(TimEntCreatedOn, timeEntry.CreatedOn); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(TimEntUpdatedOn, timeEntry.UpdatedOn); err != nil {
+		return diag.FromErr(err)
+	}
+	diags = append(diags, customFieldsSetToState(timeEntry.CustomFields, TimEntCustomField, d)...)
+
+	return diags
+}
+
+// validatePositiveHours validates that hours are greater than 0 because Redmine rejects empty time entries.
+func validatePositiveHours(i interface{}, key string) ([]string, []error) {
+	value, ok := i.(float64)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be float", key)}
+	}
+	if value <= 0 {
+		return nil, []error{fmt.Errorf("%s must be greater than 0 but is %v", key, value)}
+	}
+
+	return nil, nil
+}
+
+func timeEntryFromState(d *schema.ResourceData) *redmine.TimeEntry {
+	timeEntry := &redmine.TimeEntry{}
+	timeEntry.IssueID = d.Get(TimEntIssueID).(int)
+	// Redmine derives the project from the issue. The project in the state may still be the one of a previous issue.
+	if timeEntry.IssueID == 0 {
+		timeEntry.ProjectID = d.Get(TimEntProjectID).(int)
+	}
+	timeEntry.UserID = d.Get(TimEntUserID).(int)
+	timeEntry.SpentOn = d.Get(TimEntSpentOn).(string)
+	timeEntry.Hours = d.Get(TimEntHours).(float64)
+	timeEntry.ActivityID = d.Get(TimEntActivityID).(int)
+	timeEntry.Comments = d.Get(TimEntComments).(string)
+	timeEntry.CustomFields = customFieldsFromState(d, TimEntCustomField)
+
+	timeEntryID := d.Id()
+	if timeEntryID != "" && timeEntryID != "0" {
+		timeEntry.ID = timeEntryID
+	}
+
+	return timeEntry
+}
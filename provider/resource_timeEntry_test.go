@@ -0,0 +1,144 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/cloudogu/terraform-provider-redmine/redmine"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+const (
+	testTimeEntryTFResourceType = "redmine_time_entry"
+	testTimeEntryTFResourceName = "test_time_entry1"
+	testTimeEntryTFResource     = testTimeEntryTFResourceType + "." + testTimeEntryTFResourceName
+)
+
+const (
+	timEntKeyID         = "id"
+	timEntKeyProjectID  = "project_id"
+	timEntKeyIssueID    = "issue_id"
+	timEntKeyUserID     = "user_id"
+	timEntKeySpentOn    = "spent_on"
+	timEntKeyHours      = "hours"
+	timEntKeyActivityID = "activity_id"
+	timEntKeyComments   = "comments"
+)
+
+func TestAccTimeEntryUpdate_fromIssueToProject(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckTimeEntryDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: timeEntryAsHCL(`issue_id = redmine_issue.testissue1.id`, "1.5", "on-call"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(testTimeEntryTFResource, timEntKeyID),
+					resource.TestCheckResourceAttrPair(testTimeEntryTFResource, timEntKeyIssueID, testIssueTFResource, issKeyID),
+					// the project is derived from the issue
+					resource.TestCheckResourceAttrPair(testTimeEntryTFResource, timEntKeyProjectID, testProjectTFResource, prjKeyID),
+					resource.TestCheckResourceAttrSet(testTimeEntryTFResource, timEntKeyUserID),
+					resource.TestCheckResourceAttr(testTimeEntryTFResource, timEntKeySpentOn, "2021-06-01"),
+					resource.TestCheckResourceAttr(testTimeEntryTFResource, timEntKeyHours, "1.5"),
+					resource.TestCheckResourceAttrPair(testTimeEntryTFResource, timEntKeyActivityID, "data.redmine_time_entry_activity.development", "id"),
+					resource.TestCheckResourceAttr(testTimeEntryTFResource, timEntKeyComments, "on-call"),
+				),
+			},
+			{
+				Config: timeEntryAsHCL(`project_id = redmine_project.testproject.id`, "4", "maintenance"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(testTimeEntryTFResource, timEntKeyIssueID, "0"),
+					resource.TestCheckResourceAttrPair(testTimeEntryTFResource, timEntKeyProjectID, testProjectTFResource, prjKeyID),
+					resource.TestCheckResourceAttr(testTimeEntryTFResource, timEntKeyHours, "4"),
+					resource.TestCheckResourceAttr(testTimeEntryTFResource, timEntKeyComments, "maintenance"),
+				),
+			},
+			{
+				ResourceName:      testTimeEntryTFResource,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func Test_timeEntryFromState(t *testing.T) {
+	t.Run("should leave the project to Redmine if an issue is set", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, resourceTimeEntry().Schema, map[string]interface{}{
+			TimEntProjectID: 2,
+			TimEntIssueID:   7,
+			TimEntHours:     1.5,
+		})
+
+		timeEntry := timeEntryFromState(d)
+
+		assert.Equal(t, 7, timeEntry.IssueID)
+		assert.Equal(t, 0, timeEntry.ProjectID)
+	})
+	t.Run("should send the project without issue", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, resourceTimeEntry().Schema, map[string]interface{}{
+			TimEntProjectID: 2,
+			TimEntHours:     1.5,
+		})
+
+		timeEntry := timeEntryFromState(d)
+
+		assert.Equal(t, 0, timeEntry.IssueID)
+		assert.Equal(t, 2, timeEntry.ProjectID)
+	})
+}
+
+func Test_validatePositiveHours(t *testing.T) {
+	_, errs := validatePositiveHours(0.25, TimEntHours)
+	assert.Empty(t, errs)
+
+	_, errs = validatePositiveHours(0.0, TimEntHours)
+	assert.Len(t, errs, 1)
+
+	_, errs = validatePositiveHours(-1.0, TimEntHours)
+	assert.Len(t, errs, 1)
+}
+
+func testAccCheckTimeEntryDestroy(s *terraform.State) error {
+	cli := testAccProvider.Meta().(*redmine.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != testTimeEntryTFResourceType {
+			continue
+		}
+
+		// when
+		_, err := cli.ReadTimeEntry(context.Background(), rs.Primary.ID)
+
+		// then
+		if err == nil {
+			return fmt.Errorf("time entry (%s) still exists", rs.Primary.ID)
+		}
+		if !redmine.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return testAccCheckIssueDestroy(s)
+}
+
+// timeEntryAsHCL renders a project with an issue and a time entry which is booked on the given reference, f. i. the
+// issue_id of the issue.
+func timeEntryAsHCL(reference, hours, comments string) string {
+	return basicProjectWithDescription("testproject", "project", "a project") + "\n" +
+		issueAsHCL(testIssueTFResourceName, testProjectTFResource+".id", 2, "issue subject", "", 2) + "\n" +
+		fmt.Sprintf(`data "redmine_time_entry_activity" "development" {
+  name = "Development"
+}
+
+resource "%s" "%s" {
+  %s
+  spent_on = "2021-06-01"
+  hours = %s
+  activity_id = data.redmine_time_entry_activity.development.id
+  comments = "%s"
+}`, testTimeEntryTFResourceType, testTimeEntryTFResourceName, reference, hours, comments)
+}
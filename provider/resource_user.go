@@ -0,0 +1,297 @@
+package provider
+
+import (
+	"context"
+	"github.com/cloudogu/terraform-provider-redmine/redmine"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/pkg/errors"
+	"log"
+)
+
+const (
+	UsrID               = "id"
+	UsrLogin            = "login"
+	UsrFirstname        = "firstname"
+	UsrLastname         = "lastname"
+	UsrMail             = "mail"
+	UsrAdmin            = "admin"
+	UsrStatus           = "status"
+	UsrAuthSourceID     = "auth_source_id"
+	UsrMailNotification = "mail_notification"
+	UsrMustChangePasswd = "must_change_passwd"
+	UsrInitialPassword  = "initial_password"
+	UsrCreatedOn        = "created_on"
+	UsrUpdatedOn        = "updated_on"
+	UsrLastLoginOn      = "last_login_on"
+)
+
+const (
+	userStatusActive     = "active"
+	userStatusRegistered = "registered"
+	userStatusLocked     = "locked"
+)
+
+var userStatusToAPI = map[string]int{
+	userStatusActive:     redmine.UserStatusActive,
+	userStatusRegistered: redmine.UserStatusRegistered,
+	userStatusLocked:     redmine.UserStatusLocked,
+}
+
+// UserClient provides methods for reading and modifying Redmine users.
+type UserClient interface {
+	// CreateUser creates a user.
+	CreateUser(ctx context.Context, user *redmine.User) (*redmine.User, error)
+	// ReadUser reads a user identified by the id. The id must not be empty string or "0".
+	ReadUser(ctx context.Context, id string) (*redmine.User, error)
+	// UpdateUser updates an existing user.
+	UpdateUser(ctx context.Context, user *redmine.User) (*redmine.User, error)
+	// DeleteUser deletes a user identified by the id. The id must not be empty string or "0".
+	DeleteUser(ctx context.Context, id string) error
+}
+
+func resourceUser() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceUserCreate,
+		ReadContext:   resourceUserRead,
+		UpdateContext: resourceUserUpdate,
+		DeleteContext: resourceUserDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceUserImport,
+		},
+		Timeouts: resourceTimeouts(),
+		Schema: map[string]*schema.Schema{
+			UsrID: {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			UsrLogin: {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			UsrFirstname: {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			UsrLastname: {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			UsrMail: {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			UsrAdmin: {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			UsrStatus: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{userStatusActive, userStatusRegistered, userStatusLocked}, false),
+				Default:      userStatusActive,
+			},
+			// 0 removes the authentication source so that the user logs in with a Redmine password
+			UsrAuthSourceID: {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			// Redmine does not return the mail notification setting when a user is read. Its value is kept as
+			// configured.
+			UsrMailNotification: {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"all", "selected", "only_my_events", "only_assigned", "only_owner", "none"}, false),
+			},
+			// must_change_passwd is write-only, so a password change forced in Redmine is not detected
+			UsrMustChangePasswd: {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			UsrInitialPassword: {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+				// the password is only sent during user creation. Later changes must not lead to a diff because
+				// Redmine never returns passwords and the user may have changed it already.
+				DiffSuppressFunc: func(_, _, _ string, d *schema.ResourceData) bool {
+					return d.Id() != ""
+				},
+			},
+			UsrCreatedOn: {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			UsrUpdatedOn: {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			UsrLastLoginOn: {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceUserRead(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
+	userID := d.Get(UsrID).(string)
+
+	client := i.(UserClient)
+	user, err := client.ReadUser(ctx, userID)
+	if err != nil {
+		return handleReadError(d, "user", err)
+	}
+
+	log.Printf("user read id %s, login %s", user.ID, user.Login)
+
+	return userSetToState(user, d)
+}
+
+func resourceUserCreate(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := i.(UserClient)
+
+	user := userFromState(d)
+	user.Password = d.Get(UsrInitialPassword).(string)
+
+	createdUser, err := client.CreateUser(ctx, user)
+	if err != nil {
+		return errorToDiags(err, resourceUser().Schema)
+	}
+
+	d.SetId(createdUser.ID)
+
+	log.Printf("user create id %s, login %s", createdUser.ID, user.Login)
+
+	diagRead := resourceUserRead(ctx, d, i)
+	diags = append(diags, diagRead...)
+
+	return diags
+}
+
+func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := i.(UserClient)
+
+	user := userFromState(d)
+
+	_, err := client.UpdateUser(ctx, user)
+	if err != nil {
+		return errorToDiags(err, resourceUser().Schema)
+	}
+
+	log.Printf("user update id %s, login %s", user.ID, user.Login)
+
+	diagRead := resourceUserRead(ctx, d, i)
+	diags = append(diags, diagRead...)
+
+	return diags
+}
+
+func resourceUserDelete(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := i.(UserClient)
+
+	userID := d.Id()
+	err := client.DeleteUser(ctx, userID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("user delete id %s", userID)
+
+	return diags
+}
+
+// resourceUserImport imports a user by its numeric ID.
+func resourceUserImport(ctx context.Context, d *schema.ResourceData, i interface{}) ([]*schema.ResourceData, error) {
+	importID := d.Id()
+	if err := verifyNumericImportID("user", importID); err != nil {
+		return nil, err
+	}
+
+	client := i.(UserClient)
+	user, err := client.ReadUser(ctx, importID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not import user '%s'", importID)
+	}
+
+	if err := diagsToError(userSetToState(user, d)); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func userSetToState(user *redmine.User, d *schema.ResourceData) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	d.SetId(user.ID)
+	if err := d.Set(UsrLogin, user.Login); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(UsrFirstname, user.Firstname); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(UsrLastname, user.Lastname); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(UsrMail, user.Mail); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(UsrAdmin, user.Admin); err != nil {
+		return diag.FromErr(err)
+	}
+	for statusName, status := range userStatusToAPI {
+		if status != user.Status {
+			continue
+		}
+		if err := d.Set(UsrStatus, statusName); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if err := d.Set(UsrAuthSourceID, user.AuthSourceID); err != nil {
+		return diag.FromErr(err)
+	}
+	if user.MailNotification != "" {
+		if err := d.Set(UsrMailNotification, user.MailNotification); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if err := d.Set(UsrCreatedOn, user.CreatedOn); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(UsrUpdatedOn, user.UpdatedOn); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(UsrLastLoginOn, user.LastLoginOn); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func userFromState(d *schema.ResourceData) *redmine.User {
+	user := &redmine.User{}
+	user.Login = d.Get(UsrLogin).(string)
+	user.Firstname = d.Get(UsrFirstname).(string)
+	user.Lastname = d.Get(UsrLastname).(string)
+	user.Mail = d.Get(UsrMail).(string)
+	user.Admin = d.Get(UsrAdmin).(bool)
+	user.Status = userStatusToAPI[d.Get(UsrStatus).(string)]
+	user.AuthSourceID = d.Get(UsrAuthSourceID).(int)
+	user.MailNotification = d.Get(UsrMailNotification).(string)
+	user.MustChangePasswd = d.Get(UsrMustChangePasswd).(bool)
+
+	userID := d.Id()
+	if userID != "" && userID != "0" {
+		user.ID = userID
+	}
+
+	return user
+}
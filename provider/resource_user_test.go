@@ -0,0 +1,183 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/cloudogu/terraform-provider-redmine/redmine"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+const (
+	testUserTFResourceType = "redmine_user"
+	testUserTFResourceName = "testuser1"
+	testUserTFResource     = testUserTFResourceType + "." + testUserTFResourceName
+)
+
+const (
+	usrKeyID               = "id"
+	usrKeyLogin            = "login"
+	usrKeyFirstname        = "firstname"
+	usrKeyLastname         = "lastname"
+	usrKeyMail             = "mail"
+	usrKeyAdmin            = "admin"
+	usrKeyStatus           = "status"
+	usrKeyMailNotification = "mail_notification"
+	usrKeyMustChangePasswd = "must_change_passwd"
+	usrKeyInitialPassword  = "initial_password"
+	usrKeyCreatedOn        = "created_on"
+)
+
+func TestAccUserCreate_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: userAsHCL(testUserTFResourceName, "jdoe", "Jane", "Doe", "jdoe@example.com", false, "active"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(testUserTFResource, usrKeyID),
+					resource.TestCheckResourceAttr(testUserTFResource, usrKeyLogin, "jdoe"),
+					resource.TestCheckResourceAttr(testUserTFResource, usrKeyFirstname, "Jane"),
+					resource.TestCheckResourceAttr(testUserTFResource, usrKeyLastname, "Doe"),
+					resource.TestCheckResourceAttr(testUserTFResource, usrKeyMail, "jdoe@example.com"),
+					resource.TestCheckResourceAttr(testUserTFResource, usrKeyAdmin, "false"),
+					resource.TestCheckResourceAttr(testUserTFResource, usrKeyStatus, "active"),
+					resource.TestCheckResourceAttr(testUserTFResource, usrKeyMailNotification, "only_my_events"),
+					resource.TestCheckResourceAttr(testUserTFResource, usrKeyMustChangePasswd, "true"),
+					resource.TestCheckResourceAttrSet(testUserTFResource, usrKeyCreatedOn),
+				),
+			},
+		},
+	})
+}
+
+func TestAccUserUpdate_userValuesChanged(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: userAsHCL(testUserTFResourceName, "jdoe", "Jane", "Doe", "jdoe@example.com", false, "active"),
+			},
+			{
+				Config: userAsHCL(testUserTFResourceName, "jdoe2", "Janet", "Dough", "jdough@example.com", true, "locked"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(testUserTFResource, usrKeyLogin, "jdoe2"),
+					resource.TestCheckResourceAttr(testUserTFResource, usrKeyFirstname, "Janet"),
+					resource.TestCheckResourceAttr(testUserTFResource, usrKeyLastname, "Dough"),
+					resource.TestCheckResourceAttr(testUserTFResource, usrKeyMail, "jdough@example.com"),
+					resource.TestCheckResourceAttr(testUserTFResource, usrKeyAdmin, "true"),
+					resource.TestCheckResourceAttr(testUserTFResource, usrKeyStatus, "locked"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccUserImport(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: userAsHCL(testUserTFResourceName, "jdoe", "Jane", "Doe", "jdoe@example.com", false, "active"),
+			},
+			{
+				ResourceName:      testUserTFResource,
+				ImportState:       true,
+				ImportStateVerify: true,
+				// these values are not returned by Redmine
+				ImportStateVerifyIgnore: []string{usrKeyInitialPassword, usrKeyMailNotification, usrKeyMustChangePasswd},
+			},
+		},
+	})
+}
+
+func testAccCheckUserDestroy(s *terraform.State) error {
+	cli := testAccProvider.Meta().(*redmine.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != testUserTFResourceType {
+			continue
+		}
+
+		userID := rs.Primary.ID
+
+		// when
+		user, err := cli.ReadUser(context.Background(), userID)
+
+		// then
+		if err == nil {
+			if user.ID != "" {
+				return fmt.Errorf("user (%s) still exists", rs.Primary.ID)
+			}
+
+			return nil
+		}
+
+		if !redmine.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func userAsHCL(tfName, login, firstname, lastname, mail string, admin bool, status string) string {
+	return fmt.Sprintf(`resource "%s" "%s" {
+  login = "%s"
+  firstname = "%s"
+  lastname = "%s"
+  mail = "%s"
+  admin = %t
+  status = "%s"
+  mail_notification = "only_my_events"
+  must_change_passwd = true
+  initial_password = "Sup3rS3cret!"
+}`, testUserTFResourceType, tfName,
+		login, firstname, lastname, mail, admin, status)
+}
+
+func Test_userStatus(t *testing.T) {
+	statuses := map[string]int{
+		"active":     redmine.UserStatusActive,
+		"registered": redmine.UserStatusRegistered,
+		"locked":     redmine.UserStatusLocked,
+	}
+	for statusName, status := range statuses {
+		t.Run(statusName, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceUser().Schema, map[string]interface{}{
+				UsrLogin:     "jdoe",
+				UsrFirstname: "Jane",
+				UsrLastname:  "Doe",
+				UsrMail:      "jdoe@example.com",
+				UsrStatus:    statusName,
+			})
+
+			user := userFromState(d)
+			require.Equal(t, status, user.Status)
+
+			d = schema.TestResourceDataRaw(t, resourceUser().Schema, map[string]interface{}{})
+			diags := userSetToState(&redmine.User{ID: "8", Status: status}, d)
+			require.False(t, diags.HasError())
+			assert.Equal(t, statusName, d.Get(UsrStatus))
+		})
+	}
+}
+
+func Test_userSetToState_removedAuthSource(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceUser().Schema, map[string]interface{}{
+		UsrLogin:        "jdoe",
+		UsrAuthSourceID: 1,
+	})
+
+	diags := userSetToState(&redmine.User{ID: "8", Login: "jdoe", Status: redmine.UserStatusActive}, d)
+
+	require.False(t, diags.HasError())
+	assert.Equal(t, 0, d.Get(UsrAuthSourceID))
+}
@@ -6,19 +6,25 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/pkg/errors"
 	"log"
 	"regexp"
 )
 
 const (
-	VerID          = "id"
-	VerProjectID   = "project_id"
-	VerName        = "name"
-	VerDescription = "description"
-	VerStatus      = "status"
-	VerDueDate     = "due_date"
-	VerCreatedOn   = "created_on"
-	VerUpdatedOn   = "updated_on"
+	VerID             = "id"
+	VerProjectID      = "project_id"
+	VerName           = "name"
+	VerDescription    = "description"
+	VerStatus         = "status"
+	VerDueDate        = "due_date"
+	VerCreatedOn      = "created_on"
+	VerUpdatedOn      = "updated_on"
+	VerCustomField    = "custom_field"
+	VerSharing        = "sharing"
+	VerWikiPageTitle  = "wiki_page_title"
+	VerEstimatedHours = "estimated_hours"
+	VerSpentHours     = "spent_hours"
 )
 
 var dueDateYYYYMMDDRegexp, _ = regexp.Compile(`^(\d{4}-\d{2}-\d{2})?$`)
@@ -41,6 +47,10 @@ func resourceVersion() *schema.Resource {
 		ReadContext:   resourceVersionRead,
 		UpdateContext: resourceVersionUpdate,
 		DeleteContext: resourceVersionDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceVersionImport,
+		},
+		Timeouts: resourceTimeouts(),
 		Schema: map[string]*schema.Schema{
 			VerID: {
 				Type:     schema.TypeString,
@@ -79,6 +89,28 @@ func resourceVersion() *schema.Resource {
 				Optional: true,
 				Computed: true,
 			},
+			VerCustomField: customFieldSchema(),
+			// Redmine only allows administrators to share versions with all projects (system) and requires the
+			// permission to manage versions of the root project for hierarchy and tree
+			VerSharing: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "none",
+				ValidateFunc: validation.StringInSlice(redmine.VersionSharingModes, false),
+			},
+			VerWikiPageTitle: {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			// the estimated and spent hours of the version's issues
+			VerEstimatedHours: {
+				Type:     schema.TypeFloat,
+				Computed: true,
+			},
+			VerSpentHours: {
+				Type:     schema.TypeFloat,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -89,7 +121,7 @@ func resourceVersionRead(ctx context.Context, d *schema.ResourceData, i interfac
 	client := i.(VersionClient)
 	Version, err := client.ReadVersion(ctx, VersionID)
 	if err != nil {
-		return diag.FromErr(err)
+		return handleReadError(d, "version", err)
 	}
 
 	log.Printf("Version read id %s, project %d", Version.ID, Version.ProjectID)
@@ -105,7 +137,7 @@ func resourceVersionCreate(ctx context.Context, d *schema.ResourceData, i interf
 
 	createdVersion, err := client.CreateVersion(ctx, Version)
 	if err != nil {
-		return diag.FromErr(err)
+		return errorToDiags(err, resourceVersion().Schema)
 	}
 
 	d.SetId(createdVersion.ID)
@@ -126,7 +158,7 @@ func resourceVersionUpdate(ctx context.Context, d *schema.ResourceData, i interf
 
 	_, err := client.UpdateVersion(ctx, Version)
 	if err != nil {
-		return diag.FromErr(err)
+		return errorToDiags(err, resourceVersion().Schema)
 	}
 
 	log.Printf("Version update id %s, project %d", Version.ID, Version.ProjectID)
@@ -152,6 +184,26 @@ func resourceVersionDelete(ctx context.Context, d *schema.ResourceData, i interf
 	return diags
 }
 
+// resourceVersionImport imports a version by its numeric ID.
+func resourceVersionImport(ctx context.Context, d *schema.ResourceData, i interface{}) ([]*schema.ResourceData, error) {
+	importID := d.Id()
+	if err := verifyNumericImportID("version", importID); err != nil {
+		return nil, err
+	}
+
+	client := i.(VersionClient)
+	version, err := client.ReadVersion(ctx, importID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not import version '%s'", importID)
+	}
+
+	if err := diagsToError(VersionSetToState(version, d)); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
 func VersionSetToState(Version *redmine.Version, d *schema.ResourceData) diag.Diagnostics {
 	var diags diag.Diagnostics
 
@@ -180,6 +232,19 @@ func VersionSetToState(Version *redmine.Version, d *schema.ResourceData) diag.Di
 	if err := d.Set(VerUpdatedOn, Version.UpdatedOn); err != nil {
 		return diag.FromErr(err)
 	}
+	if err := d.Set(VerSharing, Version.Sharing); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(VerWikiPageTitle, Version.WikiPageTitle); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(VerEstimatedHours, Version.EstimatedHours); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(VerSpentHours, Version.SpentHours); err != nil {
+		return diag.FromErr(err)
+	}
+	diags = append(diags, customFieldsSetToState(Version.CustomFields, VerCustomField, d)...)
 
 	return diags
 }
@@ -193,6 +258,9 @@ func VersionFromState(d *schema.ResourceData) *redmine.Version {
 	Version.DueDate = d.Get(VerDueDate).(string)
 	Version.CreatedOn = d.Get(VerCreatedOn).(string)
 	Version.UpdatedOn = d.Get(VerUpdatedOn).(string)
+	Version.Sharing = d.Get(VerSharing).(string)
+	Version.WikiPageTitle = d.Get(VerWikiPageTitle).(string)
+	Version.CustomFields = customFieldsFromState(d, VerCustomField)
 
 	VersionID := d.Id()
 	if VersionID != "" && VersionID != "0" {
@@ -6,7 +6,7 @@ import (
 	"github.com/cloudogu/terraform-provider-redmine/redmine"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-	"strings"
+	"regexp"
 	"testing"
 )
 
@@ -17,14 +17,18 @@ const (
 )
 
 const (
-	verKeyID          = "id"
-	verKeyProjectID   = "project_id"
-	verKeyName        = "name"
-	verKeyDescription = "description"
-	verKeyStatus      = "status"
-	verKeyDueDate     = "due_date"
-	verKeyCreatedOn   = "created_on"
-	verKeyUpdatedOn   = "updated_on"
+	verKeyID             = "id"
+	verKeyProjectID      = "project_id"
+	verKeyName           = "name"
+	verKeyDescription    = "description"
+	verKeyStatus         = "status"
+	verKeyDueDate        = "due_date"
+	verKeyCreatedOn      = "created_on"
+	verKeyUpdatedOn      = "updated_on"
+	verKeySharing        = "sharing"
+	verKeyWikiPageTitle  = "wiki_page_title"
+	verKeyEstimatedHours = "estimated_hours"
+	verKeySpentHours     = "spent_hours"
 )
 
 var projectResourceBlock = basicProjectWithDescription("testproject", "project", "a project")
@@ -143,6 +147,62 @@ func TestAccVersionUpdate_versionValuesChanged(t *testing.T) {
 	})
 }
 
+func TestAccVersionUpdate_sharing(t *testing.T) {
+	sharedVersion := func(sharing string) string {
+		return projectResourceBlock + "\n" + fmt.Sprintf(`resource "%s" "%s" {
+  project_id = %s
+  name = "Release 1.0"
+  description = "shared release"
+  sharing = "%s"
+  wiki_page_title = "Release_notes"
+}`, testVersionTFResourceType, testVersionTFResourceName, projectResourceIDReference, sharing)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckVersionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: sharedVersion("descendants"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(testVersionTFResource, verKeySharing, "descendants"),
+					resource.TestCheckResourceAttr(testVersionTFResource, verKeyWikiPageTitle, "Release_notes"),
+					resource.TestCheckResourceAttr(testVersionTFResource, verKeyEstimatedHours, "0"),
+					resource.TestCheckResourceAttr(testVersionTFResource, verKeySpentHours, "0"),
+				),
+			},
+			{
+				Config: sharedVersion("system"),
+				Check:  resource.TestCheckResourceAttr(testVersionTFResource, verKeySharing, "system"),
+			},
+			{
+				Config:      sharedVersion("everyone"),
+				ExpectError: regexp.MustCompile(`expected sharing to be one of \[none descendants hierarchy tree system\]`),
+			},
+		},
+	})
+}
+
+func TestAccVersionImport(t *testing.T) {
+	tfProjectAndVersionBlocks := projectResourceBlock + "\n" +
+		VersionAsHCL(testVersionTFResourceName, projectResourceIDReference, "Sprint 1", "desc", "locked", "2021-04-01")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckVersionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: tfProjectAndVersionBlocks,
+			},
+			{
+				ResourceName:      testVersionTFResource,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
 func testAccCheckVersionDestroy(s *terraform.State) error {
 	cli := testAccProvider.Meta().(*redmine.Client)
 
@@ -165,7 +225,7 @@ func testAccCheckVersionDestroy(s *terraform.State) error {
 			return nil
 		}
 
-		if !strings.Contains(err.Error(), "version (id: "+VersionID+") was not found") {
+		if !redmine.IsNotFound(err) {
 			return err
 		}
 	}
@@ -0,0 +1,314 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/cloudogu/terraform-provider-redmine/redmine"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+	"log"
+	"strconv"
+	"strings"
+)
+
+const (
+	WikiPgID          = "id"
+	WikiPgProjectID   = "project_id"
+	WikiPgTitle       = "title"
+	WikiPgParentTitle = "parent_title"
+	WikiPgText        = "text"
+	WikiPgComments    = "comments"
+	WikiPgVersion     = "version"
+	WikiPgCreatedOn   = "created_on"
+	WikiPgUpdatedOn   = "updated_on"
+	WikiPgAttachment  = "attachment"
+)
+
+// WikiPageClient provides methods for reading and modifying the wiki pages of Redmine projects.
+type WikiPageClient interface {
+	// CreateWikiPage creates a wiki page. It fails if the page already exists.
+	CreateWikiPage(ctx context.Context, page *redmine.WikiPage) (*redmine.WikiPage, error)
+	// ReadWikiPage reads a wiki page identified by its project and its title.
+	ReadWikiPage(ctx context.Context, projectID int, title string) (*redmine.WikiPage, error)
+	// UpdateWikiPage updates an existing wiki page. It fails if the page was changed after the page's version.
+	UpdateWikiPage(ctx context.Context, page *redmine.WikiPage) (*redmine.WikiPage, error)
+	// DeleteWikiPage deletes a wiki page identified by its project and its title.
+	DeleteWikiPage(ctx context.Context, projectID int, title string) error
+}
+
+// resourceWikiPage manages a page of a project's wiki. The ID has the form <project_id>/<title>.
+func resourceWikiPage() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceWikiPageCreate,
+		ReadContext:   resourceWikiPageRead,
+		UpdateContext: resourceWikiPageUpdate,
+		DeleteContext: resourceWikiPageDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceWikiPageImport,
+		},
+		Timeouts: resourceTimeouts(),
+		// an edited attachment file updates the page although the configuration did not change
+		CustomizeDiff: customizeAttachmentDiff(WikiPgAttachment, WikiPgUpdatedOn),
+		Schema: map[string]*schema.Schema{
+			WikiPgID: {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			WikiPgProjectID: {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			// Redmine stores titles like "Start page" as "Start_page"
+			WikiPgTitle: {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: suppressEquivalentWikiTitles,
+			},
+			WikiPgParentTitle: {
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressEquivalentWikiTitles,
+			},
+			// Redmine returns the text with Windows line breaks. Whitespace at the end of the text, f. i. the final
+			// line break of a templatefile(), is ignored as well.
+			WikiPgText: {
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: suppressEquivalentWikiTexts,
+			},
+			// comments describes a change of the text and is kept as configured because Redmine only stores it
+			// together with a new version of the text
+			WikiPgComments: {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			WikiPgVersion: {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			WikiPgCreatedOn: {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			WikiPgUpdatedOn: {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			WikiPgAttachment: attachmentSchema(),
+		},
+	}
+}
+
+func suppressEquivalentWikiTitles(_, old, new string, _ *schema.ResourceData) bool {
+	return redmine.WikiTitle(old) == redmine.WikiTitle(new)
+}
+
+func suppressEquivalentWikiTexts(_, old, new string, _ *schema.ResourceData) bool {
+	return normalizeWikiText(old) == normalizeWikiText(new)
+}
+
+func normalizeWikiText(text string) string {
+	return strings.TrimRight(strings.ReplaceAll(text, "\r\n", "\n"), " \t\r\n")
+}
+
+func resourceWikiPageRead(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
+	projectID := d.Get(WikiPgProjectID).(int)
+	title := d.Get(WikiPgTitle).(string)
+
+	client := i.(WikiPageClient)
+	page, err := client.ReadWikiPage(ctx, projectID, title)
+	if err != nil {
+		return handleReadError(d, "wiki page", err)
+	}
+
+	log.Printf("wiki page read project id %d, title %s, version %d", page.ProjectID, page.Title, page.Version)
+
+	return wikiPageSetToState(page, d)
+}
+
+func resourceWikiPageCreate(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := i.(WikiPageClient)
+
+	page := wikiPageFromState(d)
+
+	attachments := attachmentsFromList(d.Get(WikiPgAttachment).([]interface{}))
+	uploads, err := uploadAttachments(ctx, i.(AttachmentClient), attachments)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	page.Uploads = uploads
+
+	createdPage, err := client.CreateWikiPage(ctx, page)
+	if err != nil {
+		return errorToDiags(err, resourceWikiPage().Schema)
+	}
+
+	d.SetId(wikiPageID(createdPage.ProjectID, createdPage.Title))
+	if err := d.Set(WikiPgAttachment, attachmentsToList(attachments)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("wiki page create project id %d, title %s", createdPage.ProjectID, createdPage.Title)
+
+	diagRead := resourceWikiPageRead(ctx, d, i)
+	diags = append(diags, diagRead...)
+
+	return diags
+}
+
+func resourceWikiPageUpdate(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := i.(WikiPageClient)
+
+	page := wikiPageFromState(d)
+
+	oldAttachments, newAttachments := d.GetChange(WikiPgAttachment)
+	attachments := attachmentsFromList(newAttachments.([]interface{}))
+	if d.HasChange(WikiPgAttachment) || len(attachments) > 0 {
+		uploads, err := updateWikiPageAttachments(ctx, i, page, attachments, attachmentsFromList(oldAttachments.([]interface{})))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		page.Uploads = uploads
+	}
+
+	_, err := client.UpdateWikiPage(ctx, page)
+	if err != nil {
+		return errorToDiags(err, resourceWikiPage().Schema)
+	}
+
+	if err := d.Set(WikiPgAttachment, attachmentsToList(attachments)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("wiki page update project id %d, title %s", page.ProjectID, page.Title)
+
+	diagRead := resourceWikiPageRead(ctx, d, i)
+	diags = append(diags, diagRead...)
+
+	return diags
+}
+
+// updateWikiPageAttachments deletes and updates the page's existing attachments and uploads the new or changed ones.
+// The returned uploads must be sent with the page.
+func updateWikiPageAttachments(ctx context.Context, i interface{}, page *redmine.WikiPage, attachments, previous []*attachment) ([]redmine.Upload, error) {
+	existingPage, err := i.(WikiPageClient).ReadWikiPage(ctx, page.ProjectID, page.Title)
+	if err != nil {
+		return nil, err
+	}
+
+	attachmentClient := i.(AttachmentClient)
+	toUpload, err := syncAttachments(ctx, attachmentClient, attachments, previous, existingPage.Attachments)
+	if err != nil {
+		return nil, err
+	}
+
+	return uploadAttachments(ctx, attachmentClient, toUpload)
+}
+
+func resourceWikiPageDelete(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := i.(WikiPageClient)
+
+	projectID := d.Get(WikiPgProjectID).(int)
+	title := d.Get(WikiPgTitle).(string)
+	err := client.DeleteWikiPage(ctx, projectID, title)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("wiki page delete project id %d, title %s", projectID, title)
+
+	return diags
+}
+
+// resourceWikiPageImport imports a wiki page by an ID of the form <project_id>/<title>.
+func resourceWikiPageImport(ctx context.Context, d *schema.ResourceData, i interface{}) ([]*schema.ResourceData, error) {
+	importID := d.Id()
+	projectID, title, err := parseWikiPageID(importID)
+	if err != nil {
+		return nil, err
+	}
+
+	client := i.(WikiPageClient)
+	page, err := client.ReadWikiPage(ctx, projectID, title)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not import wiki page '%s'", importID)
+	}
+
+	if err := diagsToError(wikiPageSetToState(page, d)); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func wikiPageID(projectID int, title string) string {
+	return fmt.Sprintf("%d/%s", projectID, redmine.WikiTitle(title))
+}
+
+// parseWikiPageID splits an ID of the form <project_id>/<title> into its parts. Titles cannot contain slashes.
+func parseWikiPageID(id string) (projectID int, title string, err error) {
+	parts := strings.Split(id, "/")
+	if len(parts) == 2 {
+		projectID, err = strconv.Atoi(parts[0])
+		title = parts[1]
+	}
+	if len(parts) != 2 || err != nil || projectID <= 0 || title == "" {
+		return 0, "", fmt.Errorf("could not import wiki page: expected an ID of the form <project_id>/<title> but found '%s'", id)
+	}
+
+	return projectID, title, nil
+}
+
+func wikiPageSetToState(page *redmine.WikiPage, d *schema.ResourceData) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	d.SetId(wikiPageID(page.ProjectID, page.Title))
+	if err := d.Set(WikiPgID, d.Id()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(WikiPgProjectID, page.ProjectID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(WikiPgTitle, page.Title); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(WikiPgParentTitle, page.ParentTitle); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(WikiPgText, page.Text); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(WikiPgVersion, page.Version); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(WikiPgCreatedOn, page.CreatedOn); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(WikiPgUpdatedOn, page.UpdatedOn); err != nil {
+		return diag.FromErr(err)
+	}
+	attachments := attachmentsFromList(d.Get(WikiPgAttachment).([]interface{}))
+	if err := attachmentsSetToState(attachments, page.Attachments, WikiPgAttachment, d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func wikiPageFromState(d *schema.ResourceData) *redmine.WikiPage {
+	page := &redmine.WikiPage{}
+	page.ProjectID = d.Get(WikiPgProjectID).(int)
+	page.Title = d.Get(WikiPgTitle).(string)
+	page.ParentTitle = d.Get(WikiPgParentTitle).(string)
+	page.Text = d.Get(WikiPgText).(string)
+	page.Comments = d.Get(WikiPgComments).(string)
+	page.Version = d.Get(WikiPgVersion).(int)
+
+	return page
+}
@@ -0,0 +1,194 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/cloudogu/terraform-provider-redmine/redmine"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+const (
+	testWikiPageTFResourceType = "redmine_wiki_page"
+	testWikiPageTFResourceName = "runbook"
+	testWikiPageTFResource     = testWikiPageTFResourceType + "." + testWikiPageTFResourceName
+)
+
+const (
+	wikiPgKeyID          = "id"
+	wikiPgKeyProjectID   = "project_id"
+	wikiPgKeyTitle       = "title"
+	wikiPgKeyParentTitle = "parent_title"
+	wikiPgKeyText        = "text"
+	wikiPgKeyVersion     = "version"
+)
+
+func TestAccWikiPageUpdate_textChanged(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckWikiPageDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: wikiPagesAsHCL("h1. Runbook\n\nRestart the service."),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(testWikiPageTFResource, wikiPgKeyID),
+					resource.TestCheckResourceAttrPair(testWikiPageTFResource, wikiPgKeyProjectID, testProjectTFResource, prjKeyID),
+					resource.TestCheckResourceAttr(testWikiPageTFResource, wikiPgKeyTitle, "Runbook"),
+					// Redmine stores the title of the parent page with an underscore
+					resource.TestCheckResourceAttr(testWikiPageTFResource, wikiPgKeyParentTitle, "Start_page"),
+					resource.TestCheckResourceAttr(testWikiPageTFResource, wikiPgKeyVersion, "1"),
+				),
+			},
+			{
+				Config: wikiPagesAsHCL("h1. Runbook\n\nRestart the service twice."),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(testWikiPageTFResource, wikiPgKeyVersion, "2"),
+				),
+			},
+			{
+				ResourceName:            testWikiPageTFResource,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"comments"},
+			},
+			{
+				ResourceName:  testWikiPageTFResource,
+				ImportState:   true,
+				ImportStateId: "Runbook",
+				ExpectError:   regexp.MustCompile("expected an ID of the form <project_id>/<title>"),
+			},
+		},
+	})
+}
+
+func TestAccWikiPageUpdate_attachments(t *testing.T) {
+	pageWithAttachment := func(content string) string {
+		return basicProjectWithDescription("testproject", "project", "a project") + "\n" +
+			fmt.Sprintf(`resource "%s" "%s" {
+  project_id = %s.id
+  title = "Runbook"
+  text = "h1. Runbook"
+  attachment {
+    filename = "runbook.txt"
+    content = "%s"
+  }
+}`, testWikiPageTFResourceType, testWikiPageTFResourceName, testProjectTFResource, content)
+	}
+	// SHA256 checksums of the attachment contents
+	const firstHash = "616379a82174fec9fd0fbc534b74004c0e3d31e61a3d021b028b94529fb21ca1"
+	const secondHash = "234a353eb9812facaeeb538d40b0577857abe6e5b278b074a9ade15edbb22c22"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckWikiPageDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: pageWithAttachment("restart the service"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(testWikiPageTFResource, "attachment.#", "1"),
+					resource.TestCheckResourceAttr(testWikiPageTFResource, "attachment.0.content_hash", firstHash),
+				),
+			},
+			{
+				Config: pageWithAttachment("restart the service twice"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(testWikiPageTFResource, "attachment.#", "1"),
+					resource.TestCheckResourceAttr(testWikiPageTFResource, "attachment.0.content_hash", secondHash),
+				),
+			},
+		},
+	})
+}
+
+func TestAccWikiPageRead_changedOutsideOfTerraform(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckWikiPageDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: wikiPagesAsHCL("h1. Runbook"),
+				Check: func(state *terraform.State) error {
+					projectID, _ := strconv.Atoi(state.RootModule().Resources[testProjectTFResource].Primary.ID)
+					cli := testAccProvider.Meta().(*redmine.Client)
+					_, err := cli.UpdateWikiPage(context.Background(), &redmine.WikiPage{ProjectID: projectID,
+						Title: "Runbook", ParentTitle: "Start page", Text: "edited in the browser"})
+					return err
+				},
+				// the changed text must be detected so that Terraform plans to restore it
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccCheckWikiPageDestroy(s *terraform.State) error {
+	cli := testAccProvider.Meta().(*redmine.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != testWikiPageTFResourceType {
+			continue
+		}
+
+		projectID, title, err := parseWikiPageID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		// when
+		_, err = cli.ReadWikiPage(context.Background(), projectID, title)
+
+		// then
+		if err == nil {
+			return fmt.Errorf("wiki page (%s) still exists", rs.Primary.ID)
+		}
+		if !redmine.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return testAccCheckProjectDestroy(s)
+}
+
+// wikiPagesAsHCL renders a project with a start page and a runbook page below it. The runbook text ends with a line
+// break like texts that are rendered by templatefile().
+func wikiPagesAsHCL(runbookText string) string {
+	return basicProjectWithDescription("testproject", "project", "a project") + "\n" +
+		fmt.Sprintf(`resource "%s" "start_page" {
+  project_id = %s.id
+  title = "Start page"
+  text = "h1. Start page"
+}
+
+resource "%s" "%s" {
+  project_id = %s.id
+  title = "Runbook"
+  parent_title = %s.start_page.title
+  text = <<EOT
+%s
+EOT
+  comments = "managed by Terraform"
+}`, testWikiPageTFResourceType, testProjectTFResource,
+			testWikiPageTFResourceType, testWikiPageTFResourceName, testProjectTFResource, testWikiPageTFResourceType,
+			runbookText)
+}
+
+func Test_suppressEquivalentWikiTexts(t *testing.T) {
+	assert.True(t, suppressEquivalentWikiTexts(wikiPgKeyText, "h1. Runbook\r\n\r\nRestart.", "h1. Runbook\n\nRestart.\n", nil))
+	assert.False(t, suppressEquivalentWikiTexts(wikiPgKeyText, "h1. Runbook\r\n\r\nRestart.", "h1. Runbook\n\nStop.\n", nil))
+}
+
+func Test_parseWikiPageID(t *testing.T) {
+	projectID, title, err := parseWikiPageID("2/Start_page")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, projectID)
+	assert.Equal(t, "Start_page", title)
+
+	_, _, err = parseWikiPageID("exampleproject/Start_page")
+
+	assert.Error(t, err)
+}
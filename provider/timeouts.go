@@ -0,0 +1,34 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"time"
+)
+
+// defaultTimeout limits each create, read, update or delete operation of a resource unless the resource's timeouts
+// block configures another duration. The context of an operation is cancelled after the timeout so that a hanging
+// request to Redmine is aborted.
+const defaultTimeout = 5 * time.Minute
+
+// resourceTimeouts returns the configurable timeouts of resources that support all CRUD operations.
+func resourceTimeouts() *schema.ResourceTimeout {
+	timeout := defaultTimeout
+
+	return &schema.ResourceTimeout{
+		Create: &timeout,
+		Read:   &timeout,
+		Update: &timeout,
+		Delete: &timeout,
+	}
+}
+
+// resourceTimeoutsWithoutUpdate returns the configurable timeouts of resources that are replaced instead of updated.
+func resourceTimeoutsWithoutUpdate() *schema.ResourceTimeout {
+	timeout := defaultTimeout
+
+	return &schema.ResourceTimeout{
+		Create: &timeout,
+		Read:   &timeout,
+		Delete: &timeout,
+	}
+}
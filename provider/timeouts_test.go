@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"context"
+	"github.com/cloudogu/terraform-provider-redmine/redmine"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func Test_resourceTimeouts(t *testing.T) {
+	t.Run("should cancel a create when its timeout expires", func(t *testing.T) {
+		sut := resourceIssueCategory()
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{
+			IssCatProjectID: 2,
+			IssCatName:      "Backend",
+			"timeouts":      []interface{}{map[string]interface{}{"create": "50ms"}},
+		})
+		diff, err := sut.Diff(context.Background(), nil, config, &hangingIssueCategoryClient{})
+		require.NoError(t, err)
+		start := time.Now()
+
+		_, diags := sut.Apply(context.Background(), nil, diff, &hangingIssueCategoryClient{})
+
+		require.True(t, diags.HasError())
+		assert.Contains(t, diags[0].Summary, context.DeadlineExceeded.Error())
+		assert.Less(t, int64(time.Since(start)), int64(5*time.Second))
+	})
+}
+
+// hangingIssueCategoryClient simulates a Redmine that does not respond until the request is cancelled.
+type hangingIssueCategoryClient struct {
+	IssueCategoryClient
+}
+
+func (c *hangingIssueCategoryClient) CreateIssueCategory(ctx context.Context, _ *redmine.IssueCategory) (*redmine.IssueCategory, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
@@ -0,0 +1,106 @@
+package redmine
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// Upload references a file that was uploaded to Redmine but is not yet attached to an entity. Redmine attaches the
+// file when the upload's token is sent together with an issue or a wiki page.
+type Upload struct {
+	Token       string `json:"token"`
+	Filename    string `json:"filename"`
+	Description string `json:"description"`
+	ContentType string `json:"content_type"`
+}
+
+// Attachment contains a file that is attached to an issue or a wiki page.
+type Attachment struct {
+	ID          int    `json:"id"`
+	Filename    string `json:"filename"`
+	Filesize    int    `json:"filesize"`
+	ContentType string `json:"content_type"`
+	Description string `json:"description"`
+	ContentURL  string `json:"content_url"`
+	// Digest contains the checksum that Redmine calculated for the file. Depending on the Redmine version it is a MD5
+	// or SHA256 checksum.
+	Digest    string `json:"digest"`
+	CreatedOn string `json:"created_on"`
+}
+
+type apiUpload struct {
+	Token       string `json:"token"`
+	Filename    string `json:"filename,omitempty"`
+	Description string `json:"description,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+}
+
+type uploadEnvelope struct {
+	Upload apiUpload `json:"upload"`
+}
+
+type apiAttachmentUpdate struct {
+	Description string `json:"description"`
+}
+
+type attachmentUpdateEnvelope struct {
+	Attachment apiAttachmentUpdate `json:"attachment"`
+}
+
+// UploadFile uploads the content of a file to Redmine and returns the upload's token. This is the first step of
+// Redmine's upload flow: the returned upload must be sent together with an issue or a wiki page to attach the file.
+// The content is streamed, so it may be an opened file. size must contain the length of the content in bytes.
+func (c *Client) UploadFile(ctx context.Context, filename string, content io.Reader, size int64) (*Upload, error) {
+	if filename == "" {
+		return nil, errors.New("could not upload file because of malformed input data: filename must be set")
+	}
+
+	path := "/uploads.json?filename=" + url.QueryEscape(filename)
+
+	var response uploadEnvelope
+	err := c.send(ctx, http.MethodPost, path, "application/octet-stream", &sizedReader{Reader: content, size: size}, &response)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while uploading file %s", filename)
+	}
+
+	return &Upload{Token: response.Upload.Token, Filename: filename}, nil
+}
+
+// UpdateAttachment changes the description of an attachment.
+func (c *Client) UpdateAttachment(ctx context.Context, attachment *Attachment) error {
+	body := attachmentUpdateEnvelope{Attachment: apiAttachmentUpdate{Description: attachment.Description}}
+
+	err := c.sendJSON(ctx, http.MethodPatch, fmt.Sprintf("/attachments/%d.json", attachment.ID), body, nil)
+	if err != nil {
+		return errors.Wrapf(err, "error while updating attachment (id: %d, filename: %s)", attachment.ID, attachment.Filename)
+	}
+
+	return nil
+}
+
+func (c *Client) DeleteAttachment(ctx context.Context, id int) error {
+	err := c.sendJSON(ctx, http.MethodDelete, fmt.Sprintf("/attachments/%d.json", id), nil, nil)
+	if err != nil {
+		return errors.Wrapf(err, "error while deleting attachment (id: %d)", id)
+	}
+
+	return nil
+}
+
+func wrapUploads(uploads []Upload) []apiUpload {
+	var apiUploads []apiUpload
+	for _, upload := range uploads {
+		apiUploads = append(apiUploads, apiUpload{
+			Token:       upload.Token,
+			Filename:    upload.Filename,
+			Description: upload.Description,
+			ContentType: upload.ContentType,
+		})
+	}
+
+	return apiUploads
+}
@@ -0,0 +1,78 @@
+package redmine
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_UploadFile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/uploads.json", r.URL.Path)
+		assert.Equal(t, "run book.txt", r.URL.Query().Get("filename"))
+		assert.Equal(t, "application/octet-stream", r.Header.Get("Content-Type"))
+		assert.Equal(t, int64(19), r.ContentLength)
+		assert.Empty(t, r.TransferEncoding)
+		body, _ := ioutil.ReadAll(r.Body)
+		assert.Equal(t, "restart the service", string(body))
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"upload":{"id":7,"token":"7.ed32257a2ab0f7526c0d72c32994c58b"}}`))
+	}))
+	defer server.Close()
+	sut, err := NewClient(Config{URL: server.URL, APIKey: "secret-key"})
+	require.NoError(t, err)
+
+	// the reader hides its length like an opened file
+	content := ioutil.NopCloser(strings.NewReader("restart the service"))
+	upload, err := sut.UploadFile(context.Background(), "run book.txt", content, 19)
+
+	require.NoError(t, err)
+	assert.Equal(t, &Upload{Token: "7.ed32257a2ab0f7526c0d72c32994c58b", Filename: "run book.txt"}, upload)
+}
+
+func TestClient_CreateIssue_uploads(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		assert.Contains(t, string(body),
+			`"uploads":[{"token":"7.ed32","filename":"runbook.txt","description":"how to restart","content_type":"text/plain"}]`)
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"issue":{"id":3,"subject":"with attachment"}}`))
+	}))
+	defer server.Close()
+	sut, err := NewClient(Config{URL: server.URL, APIKey: "secret-key"})
+	require.NoError(t, err)
+
+	_, err = sut.CreateIssue(context.Background(), &Issue{ProjectID: 1, TrackerID: 1, Subject: "with attachment",
+		Uploads: []Upload{{Token: "7.ed32", Filename: "runbook.txt", Description: "how to restart", ContentType: "text/plain"}}})
+
+	require.NoError(t, err)
+}
+
+func TestClient_ReadIssue_attachments(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "attachments,watchers,journals", r.URL.Query().Get("include"))
+		_, _ = w.Write([]byte(`{"issue":{"id":3,"subject":"with attachment","attachments":[{"id":9,
+			"filename":"runbook.txt","filesize":19,"content_type":"text/plain","description":"how to restart",
+			"content_url":"http://redmine/attachments/download/9/runbook.txt","digest":"abc","created_on":"2021-06-01T10:00:00Z"}]}}`))
+	}))
+	defer server.Close()
+	sut, err := NewClient(Config{URL: server.URL, APIKey: "secret-key"})
+	require.NoError(t, err)
+
+	issue, err := sut.ReadIssue(context.Background(), "3")
+
+	require.NoError(t, err)
+	expected := []Attachment{{ID: 9, Filename: "runbook.txt", Filesize: 19, ContentType: "text/plain",
+		Description: "how to restart", ContentURL: "http://redmine/attachments/download/9/runbook.txt", Digest: "abc",
+		CreatedOn: "2021-06-01T10:00:00Z"}}
+	assert.Equal(t, expected, issue.Attachments)
+}
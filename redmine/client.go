@@ -1,34 +1,201 @@
 package redmine
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
-	rmapi "github.com/cloudogu/go-redmine"
+	"github.com/pkg/errors"
+	"io"
+	"net/http"
 	"strconv"
+	"strings"
 )
 
 type Client struct {
 	config     Config
-	redmineAPI *rmapi.Client
+	httpClient *http.Client
 }
 
 type Config struct {
 	URL            string
 	Username       string
 	Password       string
+	APIKey         string
 	SkipCertVerify bool
+	// CACertPEM contains PEM encoded CA certificates which are trusted in addition to the system's CA certificates.
+	CACertPEM string
+	// ClientCertPEM and ClientKeyPEM contain the PEM encoded client certificate for mutual TLS authentication.
+	ClientCertPEM string
+	ClientKeyPEM  string
+	// MinTLSVersion is the minimum TLS version, f. i. tls.VersionTLS12. Go's default is used if it is 0.
+	MinTLSVersion uint16
+	// Retry configures the retries of transiently failed requests. DefaultRetryPolicy is used if it is not set.
+	Retry RetryPolicy
+	// MaxRequestsPerSecond limits the rate of requests to Redmine. 0 disables the limit.
+	MaxRequestsPerSecond float64
+	// MaxConcurrentRequests limits the number of requests that are sent to Redmine at the same time. 0 disables the
+	// limit.
+	MaxConcurrentRequests int
+}
+
+// validate checks that an endpoint and exactly one way of authentication is configured: either an API key or a
+// username/password pair.
+func (c Config) validate() error {
+	if c.URL == "" {
+		return errors.New("redmine endpoint must not be empty")
+	}
+
+	hasBasicAuth := c.Username != "" || c.Password != ""
+	hasAPIKey := c.APIKey != ""
+
+	if hasBasicAuth && hasAPIKey {
+		return errors.New("ambiguous authentication: either an API key or username/password must be configured, but not both")
+	}
+	if !hasBasicAuth && !hasAPIKey {
+		return errors.New("missing authentication: either an API key or username/password must be configured")
+	}
+	if hasBasicAuth && c.Username == "" {
+		return errors.New("invalid basic authentication: username must not be empty")
+	}
+	if c.MaxRequestsPerSecond < 0 || c.MaxConcurrentRequests < 0 {
+		return errors.New("invalid request limits: the maximum requests must not be negative")
+	}
+
+	return c.Retry.validate()
 }
 
 func NewClient(config Config) (*Client, error) {
-	redmineAPI, err := rmapi.NewClientBuilder().
-		Endpoint(config.URL).
-		AuthBasicAuth(config.Username, config.Password).
-		SkipSSLVerify(config.SkipCertVerify).
-		Build()
+	if config.Retry == (RetryPolicy{}) {
+		config.Retry = DefaultRetryPolicy
+	}
+	if err := config.validate(); err != nil {
+		return nil, errors.Wrap(err, "could not create redmine client")
+	}
+
+	httpClient, err := newHTTPClient(config)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create redmine client")
+	}
+
+	return &Client{config: config, httpClient: httpClient}, nil
+}
+
+func newHTTPClient(config Config) (*http.Client, error) {
+	tlsConfig, err := newTLSConfig(config)
 	if err != nil {
 		return nil, err
 	}
+	baseTransport := http.DefaultTransport.(*http.Transport).Clone()
+	baseTransport.TLSClientConfig = tlsConfig
+
+	// every retry counts against the request limits
+	return &http.Client{Transport: &retryTransport{
+		policy: config.Retry,
+		next:   newLimitTransport(config, &authTransport{config: config, next: baseTransport}),
+	}}, nil
+}
+
+// getJSON sends a GET request to the given API path (f. e. "/projects/1.json") and decodes the JSON response into
+// result.
+func (c *Client) getJSON(ctx context.Context, path string, result interface{}) error {
+	return c.sendJSON(ctx, http.MethodGet, path, nil, result)
+}
+
+// sendJSON sends a request to the given API path. The body is sent as JSON unless it is nil. A JSON response is
+// decoded into result unless it is nil. Unsuccessful responses are returned as one of the typed errors like
+// NotFoundError or ValidationError.
+func (c *Client) sendJSON(ctx context.Context, method, path string, body, result interface{}) error {
+	if body == nil {
+		return c.send(ctx, method, path, "", nil, result)
+	}
 
-	return &Client{config: config, redmineAPI: redmineAPI}, nil
+	bodyJSON, err := json.Marshal(body)
+	if err != nil {
+		return errors.Wrapf(err, "error while encoding %s request body for %s", method, path)
+	}
+
+	return c.send(ctx, method, path, "application/json", bytes.NewReader(bodyJSON), result)
+}
+
+// send sends a request with the given body of the given content type to the API path. The body is streamed, so it
+// may be an opened file. A JSON response is decoded into result unless it is nil.
+func (c *Client) send(ctx context.Context, method, path, contentType string, body io.Reader, result interface{}) error {
+	url := strings.TrimSuffix(c.config.URL, "/") + path
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return errors.Wrapf(err, "error while creating %s request for %s", method, path)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if sized, ok := body.(*sizedReader); ok {
+		req.ContentLength = sized.size
+		if sized.size == 0 {
+			req.Body = http.NoBody
+		}
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "error while sending %s request to %s", method, path)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		return newAPIError(method, path, res)
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	err = json.NewDecoder(res.Body).Decode(result)
+	if err != nil {
+		return errors.Wrapf(err, "error while decoding response of %s %s", method, path)
+	}
+
+	return nil
+}
+
+// sizedReader is a streamed request body whose length is known in advance, so that it is not sent with chunked
+// transfer encoding which some reverse proxies in front of Redmine reject.
+type sizedReader struct {
+	io.Reader
+	size int64
+}
+
+// pageSize is the number of entries requested per page from Redmine's list endpoints. Redmine does not return more
+// than 100 entries per page.
+const pageSize = 100
+
+// pagination contains the paging information that Redmine adds to the responses of its list endpoints.
+type pagination struct {
+	TotalCount int `json:"total_count"`
+	Offset     int `json:"offset"`
+	Limit      int `json:"limit"`
+}
+
+// getAllPages reads all entries of a Redmine list endpoint page by page. readPage is called with the path of each page
+// and must return the page's pagination and the number of entries the page contained.
+func getAllPages(path string, readPage func(pagePath string) (pagination, int, error)) error {
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+
+	offset := 0
+	for {
+		page, count, err := readPage(fmt.Sprintf("%s%soffset=%d&limit=%d", path, separator, offset, pageSize))
+		if err != nil {
+			return err
+		}
+
+		offset += count
+		if count == 0 || offset >= page.TotalCount {
+			return nil
+		}
+	}
 }
 
 func verifyIDtoInt(id string) (int, error) {
@@ -0,0 +1,254 @@
+package redmine
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testProjectJSON = `{"project":{"id":1,"name":"Example","identifier":"example"}}`
+
+func TestNewClient(t *testing.T) {
+	t.Run("should fail without URL", func(t *testing.T) {
+		_, err := NewClient(Config{Username: "admin", Password: "admin"})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "endpoint must not be empty")
+	})
+	t.Run("should fail without any credentials", func(t *testing.T) {
+		_, err := NewClient(Config{URL: "http://localhost:3000"})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "missing authentication")
+	})
+	t.Run("should fail with API key and username/password", func(t *testing.T) {
+		_, err := NewClient(Config{URL: "http://localhost:3000", Username: "admin", Password: "admin", APIKey: "abc"})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "ambiguous authentication")
+	})
+	t.Run("should fail with password but without username", func(t *testing.T) {
+		_, err := NewClient(Config{URL: "http://localhost:3000", Password: "admin"})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "username must not be empty")
+	})
+	t.Run("should fail with invalid retry policy", func(t *testing.T) {
+		_, err := NewClient(Config{URL: "http://localhost:3000", APIKey: "abc",
+			Retry: RetryPolicy{MaxAttempts: 3, MinBackoff: time.Minute, MaxBackoff: time.Second}})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid retry policy")
+	})
+}
+
+func TestClient_authentication(t *testing.T) {
+	t.Run("should send API key as header", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _, hasBasicAuth := r.BasicAuth()
+			assert.False(t, hasBasicAuth)
+			assert.Equal(t, "secret-key", r.Header.Get("X-Redmine-API-Key"))
+			assert.Empty(t, r.URL.Query().Get("key"))
+
+			_, _ = w.Write([]byte(testProjectJSON))
+		}))
+		defer server.Close()
+		sut, err := NewClient(Config{URL: server.URL, APIKey: "secret-key"})
+		require.NoError(t, err)
+
+		project, err := sut.ReadProject(context.Background(), "1")
+
+		require.NoError(t, err)
+		assert.Equal(t, "example", project.Identifier)
+	})
+	t.Run("should send username and password as basic auth", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user, password, hasBasicAuth := r.BasicAuth()
+			assert.True(t, hasBasicAuth)
+			assert.Equal(t, "admin", user)
+			assert.Equal(t, "admin123", password)
+			assert.Empty(t, r.Header.Get("X-Redmine-API-Key"))
+
+			_, _ = w.Write([]byte(testProjectJSON))
+		}))
+		defer server.Close()
+		sut, err := NewClient(Config{URL: server.URL, Username: "admin", Password: "admin123"})
+		require.NoError(t, err)
+
+		project, err := sut.ReadProject(context.Background(), "1")
+
+		require.NoError(t, err)
+		assert.Equal(t, "example", project.Identifier)
+	})
+}
+
+func TestClient_ReadProjectByIdentifier(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/projects/example.json", r.URL.Path)
+
+		_, _ = w.Write([]byte(testProjectJSON))
+	}))
+	defer server.Close()
+	sut, err := NewClient(Config{URL: server.URL + "/", APIKey: "secret-key"})
+	require.NoError(t, err)
+
+	project, err := sut.ReadProjectByIdentifier(context.Background(), "example")
+
+	require.NoError(t, err)
+	assert.Equal(t, "1", project.ID)
+	assert.Equal(t, "Example", project.Name)
+}
+
+func TestClient_ReadProject_notFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+	sut, err := NewClient(Config{URL: server.URL, APIKey: "secret-key"})
+	require.NoError(t, err)
+
+	_, err = sut.ReadProject(context.Background(), "1")
+
+	require.Error(t, err)
+	assert.True(t, IsNotFound(err))
+	assert.Contains(t, err.Error(), "error while reading project (id: 1)")
+}
+
+func TestClient_ReadProject_contextDeadline(t *testing.T) {
+	unblock := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-unblock
+	}))
+	defer server.Close()
+	defer close(unblock)
+	sut, err := NewClient(Config{URL: server.URL, APIKey: "secret-key"})
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err = sut.ReadProject(ctx, "1")
+
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestClient_writeContextDone(t *testing.T) {
+	writes := map[string]func(ctx context.Context, sut *Client) error{
+		"create": func(ctx context.Context, sut *Client) error {
+			_, err := sut.CreateIssue(ctx, &Issue{ProjectID: 1, TrackerID: 1, Subject: "Subject"})
+			return err
+		},
+		"update": func(ctx context.Context, sut *Client) error {
+			_, err := sut.UpdateIssue(ctx, &Issue{ID: "3", ProjectID: 1, TrackerID: 1, Subject: "Subject"})
+			return err
+		},
+		"delete": func(ctx context.Context, sut *Client) error {
+			return sut.DeleteIssue(ctx, "3")
+		},
+	}
+
+	for name, write := range writes {
+		write := write
+		t.Run(name+" should abort when the deadline expires", func(t *testing.T) {
+			unblock := make(chan struct{})
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				<-unblock
+			}))
+			defer server.Close()
+			defer close(unblock)
+			sut, err := NewClient(Config{URL: server.URL, APIKey: "secret-key"})
+			require.NoError(t, err)
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+
+			err = write(ctx, sut)
+
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, context.DeadlineExceeded))
+		})
+		t.Run(name+" should not send a request when cancelled", func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Fail(t, "unexpected request", "%s %s", r.Method, r.URL.Path)
+			}))
+			defer server.Close()
+			sut, err := NewClient(Config{URL: server.URL, APIKey: "secret-key"})
+			require.NoError(t, err)
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			err = write(ctx, sut)
+
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, context.Canceled))
+		})
+	}
+}
+
+func TestClient_ReadProject_associations(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/projects/2.json", r.URL.Path)
+		assert.Equal(t, "enabled_modules,trackers,issue_custom_fields", r.URL.Query().Get("include"))
+
+		_, _ = w.Write([]byte(`{"project":{"id":2,"name":"Child","identifier":"child","parent":{"id":1,"name":"Example"},
+			"enabled_modules":[{"id":4,"name":"issue_tracking"},{"id":5,"name":"wiki"}],
+			"trackers":[{"id":1,"name":"Bug"},{"id":3,"name":"Support"}],"issue_custom_fields":[]}}`))
+	}))
+	defer server.Close()
+	sut, err := NewClient(Config{URL: server.URL, APIKey: "secret-key"})
+	require.NoError(t, err)
+
+	project, err := sut.ReadProject(context.Background(), "2")
+
+	require.NoError(t, err)
+	assert.Equal(t, "1", project.ParentID)
+	assert.Equal(t, []string{"issue_tracking", "wiki"}, project.EnabledModuleNames)
+	assert.Equal(t, []int{1, 3}, project.TrackerIDs)
+	assert.Equal(t, []int{}, project.IssueCustomFieldIDs)
+}
+
+func TestClient_UpdateProject(t *testing.T) {
+	t.Run("should leave out associations that are nil", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := ioutil.ReadAll(r.Body)
+			assert.JSONEq(t, `{"project":{"id":2,"name":"Example","identifier":"example","description":"",
+				"homepage":"","is_public":false,"inherit_members":false}}`, string(body))
+
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+		sut, err := NewClient(Config{URL: server.URL, APIKey: "secret-key"})
+		require.NoError(t, err)
+
+		_, err = sut.UpdateProject(context.Background(), &Project{ID: "2", Name: "Example", Identifier: "example"})
+
+		require.NoError(t, err)
+	})
+	t.Run("should send modules, trackers and issue custom fields", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := ioutil.ReadAll(r.Body)
+			assert.JSONEq(t, `{"project":{"id":2,"name":"Example","identifier":"example","description":"",
+				"homepage":"","is_public":false,"inherit_members":false,"enabled_module_names":["wiki"],
+				"tracker_ids":[1,2],"issue_custom_field_ids":[]}}`, string(body))
+
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+		sut, err := NewClient(Config{URL: server.URL, APIKey: "secret-key"})
+		require.NoError(t, err)
+		project := &Project{ID: "2", Name: "Example", Identifier: "example", EnabledModuleNames: []string{"wiki"},
+			TrackerIDs: []int{1, 2}, IssueCustomFieldIDs: []int{}}
+
+		actual, err := sut.UpdateProject(context.Background(), project)
+
+		require.NoError(t, err)
+		assert.Equal(t, project, actual)
+	})
+}
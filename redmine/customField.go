@@ -0,0 +1,79 @@
+package redmine
+
+import (
+	"fmt"
+	rmapi "github.com/cloudogu/go-redmine"
+)
+
+// CustomField contains the value of a custom field of a Redmine entity like issues, projects or groups.
+type CustomField struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	// Multiple is true if the custom field accepts more than one value.
+	Multiple bool `json:"multiple"`
+	// Values contains the custom field's values. Single-valued custom fields contain at most one value.
+	Values []string `json:"values"`
+}
+
+func (cf CustomField) String() string {
+	return fmt.Sprintf("CustomField{ID=%d,Name=%s,Multiple=%t,Values=%v}", cf.ID, cf.Name, cf.Multiple, cf.Values)
+}
+
+func wrapCustomFields(customFields []CustomField) []*rmapi.CustomField {
+	if len(customFields) == 0 {
+		return nil
+	}
+
+	apiCustomFields := make([]*rmapi.CustomField, 0, len(customFields))
+	for _, customField := range customFields {
+		apiCustomField := &rmapi.CustomField{Id: customField.ID, Multiple: customField.Multiple}
+
+		if customField.Multiple || len(customField.Values) > 1 {
+			// an empty (but non-nil) slice clears all values of a multi-valued custom field
+			values := append([]string{}, customField.Values...)
+			apiCustomField.Value = values
+		} else if len(customField.Values) == 1 {
+			apiCustomField.Value = customField.Values[0]
+		} else {
+			apiCustomField.Value = ""
+		}
+
+		apiCustomFields = append(apiCustomFields, apiCustomField)
+	}
+
+	return apiCustomFields
+}
+
+func unwrapCustomFields(apiCustomFields []*rmapi.CustomField) []CustomField {
+	var customFields []CustomField
+
+	for _, apiCustomField := range apiCustomFields {
+		if apiCustomField == nil {
+			continue
+		}
+
+		customField := CustomField{ID: apiCustomField.Id, Name: apiCustomField.Name, Multiple: apiCustomField.Multiple}
+
+		switch value := apiCustomField.Value.(type) {
+		case nil:
+		case string:
+			if value != "" {
+				customField.Values = []string{value}
+			}
+		case []interface{}:
+			customField.Multiple = true
+			for _, singleValue := range value {
+				customField.Values = append(customField.Values, fmt.Sprint(singleValue))
+			}
+		case []string:
+			customField.Multiple = true
+			customField.Values = append(customField.Values, value...)
+		default:
+			customField.Values = []string{fmt.Sprint(value)}
+		}
+
+		customFields = append(customFields, customField)
+	}
+
+	return customFields
+}
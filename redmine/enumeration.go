@@ -0,0 +1,82 @@
+package redmine
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"strconv"
+)
+
+// Enumeration contains a value of one of Redmine's enumerations like issue priorities or time entry activities.
+type Enumeration struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	IsDefault bool   `json:"is_default"`
+	Active    bool   `json:"active"`
+}
+
+func (e *Enumeration) String() string {
+	return fmt.Sprintf("Enumeration{ID=%s,Name=%s,IsDefault=%t,Active=%t}", e.ID, e.Name, e.IsDefault, e.Active)
+}
+
+type apiEnumeration struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	IsDefault bool   `json:"is_default"`
+	// Active is only returned by Redmine 4.1 and later. Older versions only return active values.
+	Active *bool `json:"active"`
+}
+
+// ReadIssuePriorities reads all issue priorities that are configured in Redmine.
+func (c *Client) ReadIssuePriorities(ctx context.Context) ([]*Enumeration, error) {
+	priorities, err := c.readEnumeration(ctx, "issue_priorities")
+	if err != nil {
+		return nil, errors.Wrap(err, "error while reading issue priorities")
+	}
+
+	return priorities, nil
+}
+
+// ReadTimeEntryActivities reads all time entry activities that are configured in Redmine.
+func (c *Client) ReadTimeEntryActivities(ctx context.Context) ([]*Enumeration, error) {
+	activities, err := c.readEnumeration(ctx, "time_entry_activities")
+	if err != nil {
+		return nil, errors.Wrap(err, "error while reading time entry activities")
+	}
+
+	return activities, nil
+}
+
+// readEnumeration reads the values of the enumeration with the given name. Redmine returns them in a JSON array which
+// is named like the enumeration itself.
+func (c *Client) readEnumeration(ctx context.Context, name string) ([]*Enumeration, error) {
+	var response map[string]json.RawMessage
+	err := c.getJSON(ctx, fmt.Sprintf("/enumerations/%s.json", name), &response)
+	if err != nil {
+		return nil, err
+	}
+
+	rawEnumerations, ok := response[name]
+	if !ok {
+		return nil, errors.Errorf("response does not contain enumeration %s", name)
+	}
+
+	var apiEnumerations []apiEnumeration
+	if err := json.Unmarshal(rawEnumerations, &apiEnumerations); err != nil {
+		return nil, errors.Wrapf(err, "error while decoding enumeration %s", name)
+	}
+
+	var enumerations []*Enumeration
+	for _, apiEnumeration := range apiEnumerations {
+		enumeration := &Enumeration{
+			ID:        strconv.Itoa(apiEnumeration.ID),
+			Name:      apiEnumeration.Name,
+			IsDefault: apiEnumeration.IsDefault,
+			Active:    apiEnumeration.Active == nil || *apiEnumeration.Active,
+		}
+		enumerations = append(enumerations, enumeration)
+	}
+
+	return enumerations, nil
+}
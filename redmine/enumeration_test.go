@@ -0,0 +1,35 @@
+package redmine
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_ReadIssuePriorities(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/enumerations/issue_priorities.json", r.URL.Path)
+
+		// Redmine before 4.1 does not return whether a value is active
+		_, _ = w.Write([]byte(`{"issue_priorities":[{"id":1,"name":"Low","is_default":false},
+			{"id":2,"name":"Normal","is_default":true,"active":true},
+			{"id":3,"name":"High","is_default":false,"active":false}]}`))
+	}))
+	defer server.Close()
+	sut, err := NewClient(Config{URL: server.URL, APIKey: "secret-key"})
+	require.NoError(t, err)
+
+	priorities, err := sut.ReadIssuePriorities(context.Background())
+
+	require.NoError(t, err)
+	expected := []*Enumeration{
+		{ID: "1", Name: "Low", Active: true},
+		{ID: "2", Name: "Normal", IsDefault: true, Active: true},
+		{ID: "3", Name: "High"},
+	}
+	assert.Equal(t, expected, priorities)
+}
@@ -0,0 +1,109 @@
+package redmine
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// APIError contains the details of an unsuccessful Redmine API response. Callers should check for the more specific
+// error types (f. i. with errors.As) which all embed APIError.
+type APIError struct {
+	// StatusCode contains the HTTP status code of the response.
+	StatusCode int
+	// Method contains the HTTP method of the request.
+	Method string
+	// Path contains the requested API path.
+	Path string
+	// Messages contains the error messages that Redmine returned in the response's "errors" array (if any).
+	Messages []string
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("%s %s failed with HTTP status %d", e.Method, e.Path, e.StatusCode)
+	if len(e.Messages) > 0 {
+		msg += ": " + strings.Join(e.Messages, "; ")
+	}
+
+	return msg
+}
+
+// NotFoundError is returned when Redmine responds with HTTP 404, f. i. because an entity was deleted outside of
+// Terraform.
+type NotFoundError struct {
+	APIError
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("%s was not found", e.Path)
+}
+
+// UnauthorizedError is returned when Redmine responds with HTTP 401 because the credentials are missing or wrong.
+type UnauthorizedError struct {
+	APIError
+}
+
+// ForbiddenError is returned when Redmine responds with HTTP 403 because the authenticated user lacks permissions.
+type ForbiddenError struct {
+	APIError
+}
+
+// ValidationError is returned when Redmine responds with HTTP 422 because an entity contains invalid values. Messages
+// contains Redmine's validation messages which start with the humanized attribute name, f. i. "Identifier has already
+// been taken".
+type ValidationError struct {
+	APIError
+}
+
+// ConflictError is returned when Redmine responds with HTTP 409, f. i. because an entity was changed concurrently.
+type ConflictError struct {
+	APIError
+}
+
+// ServerError is returned when Redmine responds with any HTTP 5xx status.
+type ServerError struct {
+	APIError
+}
+
+type errorsResponse struct {
+	Errors []string `json:"errors"`
+}
+
+// newAPIError converts an unsuccessful response into the matching typed error.
+func newAPIError(method, path string, res *http.Response) error {
+	apiErr := APIError{StatusCode: res.StatusCode, Method: method, Path: path}
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err == nil && len(body) > 0 {
+		var response errorsResponse
+		if json.Unmarshal(body, &response) == nil {
+			apiErr.Messages = response.Errors
+		}
+	}
+
+	switch {
+	case res.StatusCode == http.StatusNotFound:
+		return &NotFoundError{apiErr}
+	case res.StatusCode == http.StatusUnauthorized:
+		return &UnauthorizedError{apiErr}
+	case res.StatusCode == http.StatusForbidden:
+		return &ForbiddenError{apiErr}
+	case res.StatusCode == http.StatusUnprocessableEntity:
+		return &ValidationError{apiErr}
+	case res.StatusCode == http.StatusConflict:
+		return &ConflictError{apiErr}
+	case res.StatusCode >= http.StatusInternalServerError:
+		return &ServerError{apiErr}
+	default:
+		return &apiErr
+	}
+}
+
+// IsNotFound returns true if the error or any error wrapped by it is a NotFoundError.
+func IsNotFound(err error) bool {
+	var notFoundErr *NotFoundError
+	return errors.As(err, &notFoundErr)
+}
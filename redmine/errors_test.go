@@ -0,0 +1,68 @@
+package redmine
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_typedErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		assertType func(t *testing.T, err error)
+	}{
+		{"unauthorized", http.StatusUnauthorized, func(t *testing.T, err error) {
+			var target *UnauthorizedError
+			assert.True(t, errors.As(err, &target))
+		}},
+		{"forbidden", http.StatusForbidden, func(t *testing.T, err error) {
+			var target *ForbiddenError
+			assert.True(t, errors.As(err, &target))
+		}},
+		{"not found", http.StatusNotFound, func(t *testing.T, err error) {
+			assert.True(t, IsNotFound(err))
+		}},
+		{"conflict", http.StatusConflict, func(t *testing.T, err error) {
+			var target *ConflictError
+			assert.True(t, errors.As(err, &target))
+		}},
+		{"validation", http.StatusUnprocessableEntity, func(t *testing.T, err error) {
+			var target *ValidationError
+			require.True(t, errors.As(err, &target))
+			assert.Equal(t, []string{"Identifier has already been taken", "Name cannot be blank"}, target.Messages)
+		}},
+		{"server error", http.StatusBadGateway, func(t *testing.T, err error) {
+			var target *ServerError
+			require.True(t, errors.As(err, &target))
+			assert.Equal(t, http.StatusBadGateway, target.StatusCode)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.statusCode)
+				_, _ = w.Write([]byte(`{"errors":["Identifier has already been taken","Name cannot be blank"]}`))
+			}))
+			defer server.Close()
+			sut, err := NewClient(Config{URL: server.URL, APIKey: "secret-key"})
+			require.NoError(t, err)
+
+			_, err = sut.CreateProject(context.Background(), &Project{Identifier: "example", Name: "Example"})
+
+			require.Error(t, err)
+			tt.assertType(t, err)
+		})
+	}
+}
+
+func TestAPIError_Error(t *testing.T) {
+	sut := &APIError{StatusCode: 422, Method: "PUT", Path: "/issues/1.json", Messages: []string{"Subject cannot be blank"}}
+
+	assert.Equal(t, "PUT /issues/1.json failed with HTTP status 422: Subject cannot be blank", sut.Error())
+}
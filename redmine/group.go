@@ -0,0 +1,169 @@
+package redmine
+
+import (
+	"context"
+	"fmt"
+	rmapi "github.com/cloudogu/go-redmine"
+	"github.com/pkg/errors"
+	"net/http"
+	"strconv"
+)
+
+type Group struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// UserIDs contains the IDs of the group's users. A nil slice leaves the users of an existing group unchanged while
+	// an empty slice removes all users from the group.
+	UserIDs      []int         `json:"user_ids"`
+	CustomFields []CustomField `json:"custom_fields"`
+}
+
+func (g *Group) String() string {
+	return fmt.Sprintf("Group{ID=%s,Name=%s,UserIDs=%v,CustomFields=%v}", g.ID, g.Name, g.UserIDs, g.CustomFields)
+}
+
+// apiGroup contains the JSON representation of a Redmine group. Redmine expects the users as user_ids in requests but
+// returns them as users in responses.
+type apiGroup struct {
+	ID           int                  `json:"id,omitempty"`
+	Name         string               `json:"name"`
+	UserIDs      *[]int               `json:"user_ids,omitempty"`
+	Users        []rmapi.IdName       `json:"users,omitempty"`
+	CustomFields []*rmapi.CustomField `json:"custom_fields,omitempty"`
+}
+
+type groupEnvelope struct {
+	Group apiGroup `json:"group"`
+}
+
+type groupUserRequest struct {
+	UserID int `json:"user_id"`
+}
+
+func (c *Client) CreateGroup(ctx context.Context, group *Group) (*Group, error) {
+	apiGroup := wrapGroup(group)
+
+	var response groupEnvelope
+	err := c.sendJSON(ctx, http.MethodPost, "/groups.json", groupEnvelope{Group: *apiGroup}, &response)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while creating group (name: %s)", group.Name)
+	}
+
+	return unwrapGroup(&response.Group), nil
+}
+
+func (c *Client) ReadGroup(ctx context.Context, id string) (*Group, error) {
+	idInt, err := verifyIDtoInt(id)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read group because of malformed input data")
+	}
+
+	var response groupEnvelope
+	err = c.getJSON(ctx, fmt.Sprintf("/groups/%d.json?include=users", idInt), &response)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while reading group (id: %d)", idInt)
+	}
+
+	return unwrapGroup(&response.Group), nil
+}
+
+func (c *Client) UpdateGroup(ctx context.Context, group *Group) (*Group, error) {
+	idInt, err := verifyIDtoInt(group.ID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not update group (id: %s, name: %s) because of malformed input data",
+			group.ID, group.Name)
+	}
+
+	apiGroup := *wrapGroup(group)
+
+	err = c.sendJSON(ctx, http.MethodPut, fmt.Sprintf("/groups/%d.json", idInt), groupEnvelope{Group: apiGroup}, nil)
+	if err != nil {
+		return group, errors.Wrapf(err, "error while updating group (id: %d, name: %s)", idInt, group.Name)
+	}
+
+	return unwrapGroup(&apiGroup), nil
+}
+
+func (c *Client) DeleteGroup(ctx context.Context, id string) error {
+	idInt, err := verifyIDtoInt(id)
+	if err != nil {
+		return errors.Wrap(err, "could not delete group because of malformed input data")
+	}
+
+	err = c.sendJSON(ctx, http.MethodDelete, fmt.Sprintf("/groups/%d.json", idInt), nil, nil)
+	if err != nil {
+		return errors.Wrapf(err, "error while deleting group (id: %d)", idInt)
+	}
+
+	return nil
+}
+
+// AddGroupUser adds a single user to a group without touching the group's other users.
+func (c *Client) AddGroupUser(ctx context.Context, groupID string, userID int) error {
+	idInt, err := verifyIDtoInt(groupID)
+	if err != nil {
+		return errors.Wrap(err, "could not add user to group because of malformed input data")
+	}
+
+	err = c.sendJSON(ctx, http.MethodPost, fmt.Sprintf("/groups/%d/users.json", idInt), groupUserRequest{UserID: userID}, nil)
+	if err != nil {
+		return errors.Wrapf(err, "error while adding user to group (group id: %d, user id: %d)", idInt, userID)
+	}
+
+	return nil
+}
+
+// RemoveGroupUser removes a single user from a group without touching the group's other users.
+func (c *Client) RemoveGroupUser(ctx context.Context, groupID string, userID int) error {
+	idInt, err := verifyIDtoInt(groupID)
+	if err != nil {
+		return errors.Wrap(err, "could not remove user from group because of malformed input data")
+	}
+
+	err = c.sendJSON(ctx, http.MethodDelete, fmt.Sprintf("/groups/%d/users/%d.json", idInt, userID), nil, nil)
+	if err != nil {
+		return errors.Wrapf(err, "error while removing user from group (group id: %d, user id: %d)", idInt, userID)
+	}
+
+	return nil
+}
+
+func wrapGroup(group *Group) *apiGroup {
+	apiGroup := &apiGroup{
+		Name:         group.Name,
+		CustomFields: wrapCustomFields(group.CustomFields),
+	}
+
+	if group.ID != "" {
+		apiGroup.ID, _ = strconv.Atoi(group.ID)
+	}
+
+	if group.UserIDs != nil {
+		userIDs := append([]int{}, group.UserIDs...)
+		apiGroup.UserIDs = &userIDs
+	}
+
+	return apiGroup
+}
+
+func unwrapGroup(apiGroup *apiGroup) *Group {
+	group := &Group{
+		Name:         apiGroup.Name,
+		CustomFields: unwrapCustomFields(apiGroup.CustomFields),
+	}
+
+	if apiGroup.ID != 0 {
+		group.ID = strconv.Itoa(apiGroup.ID)
+	}
+
+	if apiGroup.Users != nil {
+		group.UserIDs = []int{}
+		for _, user := range apiGroup.Users {
+			group.UserIDs = append(group.UserIDs, user.Id)
+		}
+	} else if apiGroup.UserIDs != nil {
+		group.UserIDs = append([]int{}, *apiGroup.UserIDs...)
+	}
+
+	return group
+}
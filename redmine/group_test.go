@@ -0,0 +1,70 @@
+package redmine
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_UpdateGroup(t *testing.T) {
+	t.Run("should leave users unchanged if user IDs are nil", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := ioutil.ReadAll(r.Body)
+			assert.JSONEq(t, `{"group":{"id":5,"name":"developers"}}`, string(body))
+
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+		sut, err := NewClient(Config{URL: server.URL, APIKey: "secret-key"})
+		require.NoError(t, err)
+
+		_, err = sut.UpdateGroup(context.Background(), &Group{ID: "5", Name: "developers"})
+
+		require.NoError(t, err)
+	})
+	t.Run("should remove all users if user IDs are empty", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := ioutil.ReadAll(r.Body)
+			assert.JSONEq(t, `{"group":{"id":5,"name":"developers","user_ids":[]}}`, string(body))
+
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+		sut, err := NewClient(Config{URL: server.URL, APIKey: "secret-key"})
+		require.NoError(t, err)
+
+		_, err = sut.UpdateGroup(context.Background(), &Group{ID: "5", Name: "developers", UserIDs: []int{}})
+
+		require.NoError(t, err)
+	})
+}
+
+func TestClient_ReadGroup(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/groups/5.json", r.URL.Path)
+		assert.Equal(t, "users", r.URL.Query().Get("include"))
+
+		_, _ = w.Write([]byte(`{"group":{"id":5,"name":"developers","users":[{"id":3,"name":"Jane Doe"}],
+			"custom_fields":[{"id":1,"name":"Department","value":"R&D"},
+			{"id":2,"name":"Locations","multiple":true,"value":["Berlin","Brunswick"]}]}}`))
+	}))
+	defer server.Close()
+	sut, err := NewClient(Config{URL: server.URL, APIKey: "secret-key"})
+	require.NoError(t, err)
+
+	group, err := sut.ReadGroup(context.Background(), "5")
+
+	require.NoError(t, err)
+	assert.Equal(t, "5", group.ID)
+	assert.Equal(t, []int{3}, group.UserIDs)
+	expectedCustomFields := []CustomField{
+		{ID: 1, Name: "Department", Values: []string{"R&D"}},
+		{ID: 2, Name: "Locations", Multiple: true, Values: []string{"Berlin", "Brunswick"}},
+	}
+	assert.Equal(t, expectedCustomFields, group.CustomFields)
+}
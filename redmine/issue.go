@@ -2,9 +2,11 @@ package redmine
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	rmapi "github.com/cloudogu/go-redmine"
 	"github.com/pkg/errors"
+	"net/http"
 	"strconv"
 )
 
@@ -17,8 +19,128 @@ type Issue struct {
 	ParentIssueID int    `json:"parent_issue_id"`
 	PriorityID    int    `json:"priority_id"`
 	CategoryID    int    `json:"category_id"`
-	CreatedOn     string `json:"created_on"`
-	UpdatedOn     string `json:"updated_on"`
+	AssignedToID  int    `json:"assigned_to_id"`
+	StatusID      int    `json:"status_id"`
+	// StartDate contains the start date in the format YYYY-MM-DD. An empty start date is not sent to Redmine.
+	StartDate string `json:"start_date"`
+	// DueDate contains the due date in the format YYYY-MM-DD. An empty due date removes the due date.
+	DueDate        string  `json:"due_date"`
+	DoneRatio      int     `json:"done_ratio"`
+	EstimatedHours float64 `json:"estimated_hours"`
+	IsPrivate      bool    `json:"is_private"`
+	FixedVersionID int     `json:"fixed_version_id"`
+	CreatedOn      string  `json:"created_on"`
+	UpdatedOn      string  `json:"updated_on"`
+	// CustomFields contains the values of the issue's custom fields. Custom fields that are not contained are left
+	// unchanged.
+	CustomFields []CustomField `json:"custom_fields"`
+	// Uploads contains uploaded files which are attached to the issue when it is created or updated.
+	Uploads []Upload `json:"uploads"`
+	// Attachments contains the files that are attached to the issue. It is only filled when reading issues.
+	Attachments []Attachment `json:"attachments"`
+	// WatcherUserIDs contains the IDs of the users that watch the issue. A nil slice leaves the watchers unchanged.
+	WatcherUserIDs []int `json:"watcher_user_ids"`
+	// Notes is added to the issue's history as journal entry when the issue is updated.
+	Notes string `json:"notes"`
+	// PrivateNotes makes the Notes only visible to users who may view private notes.
+	PrivateNotes bool `json:"private_notes"`
+	// Journals contains the history of the issue. It is only filled when reading issues.
+	Journals []Journal `json:"journals"`
+}
+
+// Journal contains an entry of an issue's history which is created for each update of the issue.
+type Journal struct {
+	ID           int    `json:"id"`
+	UserID       int    `json:"user_id"`
+	UserName     string `json:"user_name"`
+	Notes        string `json:"notes"`
+	PrivateNotes bool   `json:"private_notes"`
+	CreatedOn    string `json:"created_on"`
+}
+
+type apiJournal struct {
+	ID           int           `json:"id"`
+	User         *rmapi.IdName `json:"user,omitempty"`
+	Notes        string        `json:"notes"`
+	PrivateNotes bool          `json:"private_notes"`
+	CreatedOn    string        `json:"created_on"`
+}
+
+// apiIssue contains the JSON representation of a Redmine issue. Redmine expects references as IDs in requests (f. i.
+// status_id) but returns them as objects in responses (f. i. status).
+type apiIssue struct {
+	ID             int                  `json:"id,omitempty"`
+	ProjectID      int                  `json:"project_id,omitempty"`
+	Project        *rmapi.IdName        `json:"project,omitempty"`
+	TrackerID      int                  `json:"tracker_id,omitempty"`
+	Tracker        *rmapi.IdName        `json:"tracker,omitempty"`
+	Subject        string               `json:"subject"`
+	Description    string               `json:"description"`
+	ParentIssueID  nullableID           `json:"parent_issue_id"`
+	Parent         *rmapi.Id            `json:"parent,omitempty"`
+	PriorityID     int                  `json:"priority_id,omitempty"`
+	Priority       *rmapi.IdName        `json:"priority,omitempty"`
+	CategoryID     nullableID           `json:"category_id"`
+	Category       *rmapi.IdName        `json:"category,omitempty"`
+	AssignedToID   nullableID           `json:"assigned_to_id"`
+	AssignedTo     *rmapi.IdName        `json:"assigned_to,omitempty"`
+	StatusID       int                  `json:"status_id,omitempty"`
+	Status         *rmapi.IdName        `json:"status,omitempty"`
+	FixedVersionID nullableID           `json:"fixed_version_id"`
+	FixedVersion   *rmapi.IdName        `json:"fixed_version,omitempty"`
+	StartDate      string               `json:"start_date,omitempty"`
+	DueDate        string               `json:"due_date"`
+	DoneRatio      int                  `json:"done_ratio"`
+	EstimatedHours *float64             `json:"estimated_hours"`
+	IsPrivate      bool                 `json:"is_private"`
+	CreatedOn      string               `json:"created_on,omitempty"`
+	UpdatedOn      string               `json:"updated_on,omitempty"`
+	CustomFields   []*rmapi.CustomField `json:"custom_fields,omitempty"`
+	Uploads        []apiUpload          `json:"uploads,omitempty"`
+	Attachments    []Attachment         `json:"attachments,omitempty"`
+	WatcherUserIDs []int                `json:"watcher_user_ids,omitempty"`
+	Watchers       []rmapi.IdName       `json:"watchers,omitempty"`
+	Notes          string               `json:"notes,omitempty"`
+	PrivateNotes   bool                 `json:"private_notes,omitempty"`
+	Journals       []apiJournal         `json:"journals,omitempty"`
+}
+
+// nullableID contains the ID of a referenced entity. A zero ID is sent as empty string which makes Redmine remove the
+// reference, f. i. the assignee of an issue.
+type nullableID int
+
+func (id nullableID) MarshalJSON() ([]byte, error) {
+	if id == 0 {
+		return []byte(`""`), nil
+	}
+
+	return json.Marshal(int(id))
+}
+
+func (id *nullableID) UnmarshalJSON(data []byte) error {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	switch typedValue := value.(type) {
+	case float64:
+		*id = nullableID(typedValue)
+	case string:
+		intValue, _ := strconv.Atoi(typedValue)
+		*id = nullableID(intValue)
+	default:
+		*id = 0
+	}
+
+	return nil
+}
+
+// issueIncludes contains the associations that are read together with an issue.
+const issueIncludes = "attachments,watchers,journals"
+
+type issueEnvelope struct {
+	Issue apiIssue `json:"issue"`
 }
 
 func (i *Issue) String() string {
@@ -29,12 +151,13 @@ func (i *Issue) String() string {
 func (c *Client) CreateIssue(ctx context.Context, issue *Issue) (*Issue, error) {
 	apiIssue := wrapIssue(issue)
 
-	createdAPIIssue, err := c.redmineAPI.CreateIssue(*apiIssue)
+	var response issueEnvelope
+	err := c.sendJSON(ctx, http.MethodPost, "/issues.json", issueEnvelope{Issue: *apiIssue}, &response)
 	if err != nil {
 		return nil, errors.Wrapf(err, "error while creating issue (project id: %d, subject: %s)", issue.ProjectID, issue.Subject)
 	}
 
-	actualIssue := unwrapIssue(createdAPIIssue)
+	actualIssue := unwrapIssue(&response.Issue)
 
 	return actualIssue, nil
 }
@@ -45,42 +168,93 @@ func (c *Client) ReadIssue(ctx context.Context, id string) (Issue *Issue, err er
 		return nil, errors.Wrap(err, "could not read issue because of malformed input data")
 	}
 
-	apiIssue, err := c.redmineAPI.Issue(idInt)
+	var response issueEnvelope
+	err = c.getJSON(ctx, fmt.Sprintf("/issues/%d.json?include=%s", idInt, issueIncludes), &response)
 	if err != nil {
 		return Issue, errors.Wrapf(err, "error while reading issue (id: %d)", idInt)
 	}
 
-	Issue = unwrapIssue(apiIssue)
+	Issue = unwrapIssue(&response.Issue)
 
 	return Issue, nil
 }
 
 func (c *Client) UpdateIssue(ctx context.Context, issue *Issue) (updatedIssue *Issue, err error) {
-	_, err = verifyIDtoInt(issue.ID)
+	idInt, err := verifyIDtoInt(issue.ID)
 	if err != nil {
 		return nil, errors.Wrapf(err, "could not update issue (id: %s, subject: %s) because of malformed input data",
 			issue.ID, issue.Subject)
 	}
 
 	apiIssue := *wrapIssue(issue)
+	// Redmine only accepts watcher_user_ids when an issue is created, so the watchers are updated separately
+	apiIssue.WatcherUserIDs = nil
 
-	err = c.redmineAPI.UpdateIssue(apiIssue)
+	err = c.sendJSON(ctx, http.MethodPut, fmt.Sprintf("/issues/%d.json", idInt), issueEnvelope{Issue: apiIssue}, nil)
 	if err != nil {
-		return issue, errors.Wrapf(err, "error while updating issue (id: %d, subject: %s)", apiIssue.Id, issue.Subject)
+		return issue, errors.Wrapf(err, "error while updating issue (id: %d, subject: %s)", apiIssue.ID, issue.Subject)
 	}
 
-	issue = unwrapIssue(&apiIssue)
+	if issue.WatcherUserIDs != nil {
+		err = c.updateIssueWatchers(ctx, idInt, issue.WatcherUserIDs)
+		if err != nil {
+			return issue, errors.Wrapf(err, "error while updating watchers of issue (id: %d, subject: %s)", idInt, issue.Subject)
+		}
+	}
 
 	return issue, nil
 }
 
+// updateIssueWatchers adds and removes watchers so that exactly the given users watch the issue. Watchers that are
+// already set are not touched.
+func (c *Client) updateIssueWatchers(ctx context.Context, issueID int, watcherUserIDs []int) error {
+	var response issueEnvelope
+	err := c.getJSON(ctx, fmt.Sprintf("/issues/%d.json?include=watchers", issueID), &response)
+	if err != nil {
+		return err
+	}
+
+	wanted := map[int]bool{}
+	for _, userID := range watcherUserIDs {
+		wanted[userID] = true
+	}
+	current := map[int]bool{}
+	for _, watcher := range response.Issue.Watchers {
+		current[watcher.Id] = true
+	}
+
+	for _, userID := range watcherUserIDs {
+		if current[userID] {
+			continue
+		}
+		body := map[string]int{"user_id": userID}
+		err = c.sendJSON(ctx, http.MethodPost, fmt.Sprintf("/issues/%d/watchers.json", issueID), body, nil)
+		if err != nil {
+			return err
+		}
+		current[userID] = true
+	}
+
+	for _, watcher := range response.Issue.Watchers {
+		if wanted[watcher.Id] {
+			continue
+		}
+		err = c.sendJSON(ctx, http.MethodDelete, fmt.Sprintf("/issues/%d/watchers/%d.json", issueID, watcher.Id), nil, nil)
+		if err != nil && !IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (c *Client) DeleteIssue(ctx context.Context, id string) error {
 	idInt, err := verifyIDtoInt(id)
 	if err != nil {
 		return errors.Wrap(err, "could not delete issue because of malformed input data")
 	}
 
-	err = c.redmineAPI.DeleteIssue(idInt)
+	err = c.sendJSON(ctx, http.MethodDelete, fmt.Sprintf("/issues/%d.json", idInt), nil, nil)
 	if err != nil {
 		return errors.Wrapf(err, "error while deleteting issue (id: %d)", idInt)
 	}
@@ -88,59 +262,77 @@ func (c *Client) DeleteIssue(ctx context.Context, id string) error {
 	return nil
 }
 
-func wrapIssue(issue *Issue) *rmapi.Issue {
-	apiIssue := &rmapi.Issue{
-		ProjectId:   issue.ProjectID,
-		Project:     &rmapi.IdName{Id: issue.ProjectID},
-		TrackerId:   issue.TrackerID,
-		Tracker:     &rmapi.IdName{Id: issue.TrackerID},
-		Subject:     issue.Subject,
-		Description: issue.Description,
-		CreatedOn:   issue.CreatedOn,
-		UpdatedOn:   issue.UpdatedOn,
+func wrapIssue(issue *Issue) *apiIssue {
+	apiIssue := &apiIssue{
+		ProjectID:      issue.ProjectID,
+		TrackerID:      issue.TrackerID,
+		Subject:        issue.Subject,
+		Description:    issue.Description,
+		ParentIssueID:  nullableID(issue.ParentIssueID),
+		PriorityID:     issue.PriorityID,
+		CategoryID:     nullableID(issue.CategoryID),
+		AssignedToID:   nullableID(issue.AssignedToID),
+		StatusID:       issue.StatusID,
+		FixedVersionID: nullableID(issue.FixedVersionID),
+		StartDate:      issue.StartDate,
+		DueDate:        issue.DueDate,
+		DoneRatio:      issue.DoneRatio,
+		IsPrivate:      issue.IsPrivate,
+		CreatedOn:      issue.CreatedOn,
+		UpdatedOn:      issue.UpdatedOn,
+		CustomFields:   wrapCustomFields(issue.CustomFields),
+		Uploads:        wrapUploads(issue.Uploads),
+		WatcherUserIDs: issue.WatcherUserIDs,
+		Notes:          issue.Notes,
+		PrivateNotes:   issue.PrivateNotes,
 	}
 
 	if issue.ID != "" {
-		apiIssue.Id, _ = strconv.Atoi(issue.ID)
-	}
-
-	if issue.ParentIssueID != 0 {
-		if apiIssue.Parent == nil {
-			apiIssue.Parent = &rmapi.Id{}
-		}
-		apiIssue.Parent.Id = issue.ParentIssueID
-		apiIssue.ParentId = issue.ParentIssueID
-	}
-
-	if issue.PriorityID != 0 {
-		if apiIssue.Priority == nil {
-			apiIssue.Priority = &rmapi.IdName{}
-		}
-		apiIssue.Priority.Id = issue.PriorityID
-		apiIssue.PriorityId = issue.PriorityID
+		apiIssue.ID, _ = strconv.Atoi(issue.ID)
 	}
 
-	if issue.CategoryID != 0 {
-		if apiIssue.Category == nil {
-			apiIssue.Category = &rmapi.IdName{}
-		}
-		apiIssue.Category.Id = issue.CategoryID
-		apiIssue.CategoryId = issue.CategoryID
+	if issue.EstimatedHours != 0 {
+		estimatedHours := issue.EstimatedHours
+		apiIssue.EstimatedHours = &estimatedHours
 	}
 
 	return apiIssue
 }
 
-func unwrapIssue(apiIssue *rmapi.Issue) *Issue {
+func unwrapIssue(apiIssue *apiIssue) *Issue {
 	issue := &Issue{
-		Subject:     apiIssue.Subject,
-		Description: apiIssue.Description,
-		CreatedOn:   apiIssue.CreatedOn,
-		UpdatedOn:   apiIssue.UpdatedOn,
+		Subject:      apiIssue.Subject,
+		Description:  apiIssue.Description,
+		StartDate:    apiIssue.StartDate,
+		DueDate:      apiIssue.DueDate,
+		DoneRatio:    apiIssue.DoneRatio,
+		IsPrivate:    apiIssue.IsPrivate,
+		CreatedOn:    apiIssue.CreatedOn,
+		UpdatedOn:    apiIssue.UpdatedOn,
+		CustomFields: unwrapCustomFields(apiIssue.CustomFields),
+		Attachments:  apiIssue.Attachments,
+	}
+
+	if apiIssue.Watchers != nil {
+		issue.WatcherUserIDs = idsOf(apiIssue.Watchers)
+	}
+
+	for _, apiJournal := range apiIssue.Journals {
+		journal := Journal{
+			ID:           apiJournal.ID,
+			Notes:        apiJournal.Notes,
+			PrivateNotes: apiJournal.PrivateNotes,
+			CreatedOn:    apiJournal.CreatedOn,
+		}
+		if apiJournal.User != nil {
+			journal.UserID = apiJournal.User.Id
+			journal.UserName = apiJournal.User.Name
+		}
+		issue.Journals = append(issue.Journals, journal)
 	}
 
-	if apiIssue.Id != 0 {
-		issue.ID = strconv.Itoa(apiIssue.Id)
+	if apiIssue.ID != 0 {
+		issue.ID = strconv.Itoa(apiIssue.ID)
 	}
 	if apiIssue.Parent != nil {
 		issue.ParentIssueID = apiIssue.Parent.Id
@@ -161,5 +353,21 @@ func unwrapIssue(apiIssue *rmapi.Issue) *Issue {
 		issue.CategoryID = apiIssue.Category.Id
 	}
 
+	if apiIssue.AssignedTo != nil {
+		issue.AssignedToID = apiIssue.AssignedTo.Id
+	}
+
+	if apiIssue.Status != nil {
+		issue.StatusID = apiIssue.Status.Id
+	}
+
+	if apiIssue.FixedVersion != nil {
+		issue.FixedVersionID = apiIssue.FixedVersion.Id
+	}
+
+	if apiIssue.EstimatedHours != nil {
+		issue.EstimatedHours = *apiIssue.EstimatedHours
+	}
+
 	return issue
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	rmapi "github.com/cloudogu/go-redmine"
 	"github.com/pkg/errors"
+	"net/http"
 	"strconv"
 )
 
@@ -12,41 +13,61 @@ type IssueCategory struct {
 	ID        string `json:"id"`
 	ProjectID int    `json:"project_id"`
 	Name      string `json:"name"`
+	// AssignedToID references the user or group that new issues of the category are assigned to. It must be a member
+	// of the project. A zero ID removes the assignee.
+	AssignedToID int `json:"assigned_to_id"`
+}
+
+// apiIssueCategory contains the JSON representation of an issue category. Redmine expects the assignee as
+// assigned_to_id in requests but returns it as assigned_to in responses.
+type apiIssueCategory struct {
+	ID           int           `json:"id,omitempty"`
+	Project      *rmapi.IdName `json:"project,omitempty"`
+	Name         string        `json:"name"`
+	AssignedToID nullableID    `json:"assigned_to_id"`
+	AssignedTo   *rmapi.IdName `json:"assigned_to,omitempty"`
+}
+
+type issueCategoryEnvelope struct {
+	IssueCategory apiIssueCategory `json:"issue_category"`
 }
 
 func (i *IssueCategory) String() string {
 	return fmt.Sprintf("IssueCategory{ID=%s,ProjectID=%d,Name=%s}", i.ID, i.ProjectID, i.Name)
 }
 
-func (c *Client) CreateIssueCategory(_ context.Context, IssueCategory *IssueCategory) (*IssueCategory, error) {
+func (c *Client) CreateIssueCategory(ctx context.Context, IssueCategory *IssueCategory) (*IssueCategory, error) {
 	apiIssueCategory := wrapIssueCategory(IssueCategory)
 
-	createdAPIIssueCategory, err := c.redmineAPI.CreateIssueCategory(*apiIssueCategory)
+	var response issueCategoryEnvelope
+	path := fmt.Sprintf("/projects/%d/issue_categories.json", IssueCategory.ProjectID)
+	err := c.sendJSON(ctx, http.MethodPost, path, issueCategoryEnvelope{IssueCategory: *apiIssueCategory}, &response)
 	if err != nil {
 		return nil, errors.Wrapf(err, "error while creating issue category (project id: %d, name: %s)", IssueCategory.ProjectID, IssueCategory.Name)
 	}
 
-	actualIssueCategory := unwrapIssueCategory(createdAPIIssueCategory)
+	actualIssueCategory := unwrapIssueCategory(&response.IssueCategory)
 
 	return actualIssueCategory, nil
 }
 
-func (c *Client) ReadIssueCategory(_ context.Context, id string) (IssueCategory *IssueCategory, err error) {
+func (c *Client) ReadIssueCategory(ctx context.Context, id string) (IssueCategory *IssueCategory, err error) {
 	idInt, err := verifyIDtoInt(id)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not read issue category because of malformed input data")
 	}
 
-	apiIssueCategory, err := c.redmineAPI.IssueCategory(idInt)
+	var response issueCategoryEnvelope
+	err = c.getJSON(ctx, fmt.Sprintf("/issue_categories/%d.json", idInt), &response)
 	if err != nil {
 		return IssueCategory, errors.Wrapf(err, "error while reading issue category (id: %d)", idInt)
 	}
 
-	return unwrapIssueCategory(apiIssueCategory), nil
+	return unwrapIssueCategory(&response.IssueCategory), nil
 }
 
-func (c *Client) UpdateIssueCategory(_ context.Context, IssueCategory *IssueCategory) (updatedIssueCategory *IssueCategory, err error) {
-	_, err = verifyIDtoInt(IssueCategory.ID)
+func (c *Client) UpdateIssueCategory(ctx context.Context, IssueCategory *IssueCategory) (updatedIssueCategory *IssueCategory, err error) {
+	idInt, err := verifyIDtoInt(IssueCategory.ID)
 	if err != nil {
 		return nil, errors.Wrapf(err, "could not update issue category (id: %s, name: %s) because of malformed input data",
 			IssueCategory.ID, IssueCategory.Name)
@@ -54,21 +75,22 @@ func (c *Client) UpdateIssueCategory(_ context.Context, IssueCategory *IssueCate
 
 	apiIssueCategory := *wrapIssueCategory(IssueCategory)
 
-	err = c.redmineAPI.UpdateIssueCategory(apiIssueCategory)
+	path := fmt.Sprintf("/issue_categories/%d.json", idInt)
+	err = c.sendJSON(ctx, http.MethodPut, path, issueCategoryEnvelope{IssueCategory: apiIssueCategory}, nil)
 	if err != nil {
-		return IssueCategory, errors.Wrapf(err, "error while updating issue category (id: %d, name: %s)", apiIssueCategory.Id, IssueCategory.Name)
+		return IssueCategory, errors.Wrapf(err, "error while updating issue category (id: %d, name: %s)", idInt, IssueCategory.Name)
 	}
 
-	return unwrapIssueCategory(&apiIssueCategory), nil
+	return IssueCategory, nil
 }
 
-func (c *Client) DeleteIssueCategory(_ context.Context, id string) error {
+func (c *Client) DeleteIssueCategory(ctx context.Context, id string) error {
 	idInt, err := verifyIDtoInt(id)
 	if err != nil {
 		return errors.Wrap(err, "could not delete issue category because of malformed input data")
 	}
 
-	err = c.redmineAPI.DeleteIssueCategory(idInt)
+	err = c.sendJSON(ctx, http.MethodDelete, fmt.Sprintf("/issue_categories/%d.json", idInt), nil, nil)
 	if err != nil {
 		return errors.Wrapf(err, "error while deleteting issue category (id: %d)", idInt)
 	}
@@ -76,24 +98,24 @@ func (c *Client) DeleteIssueCategory(_ context.Context, id string) error {
 	return nil
 }
 
-func wrapIssueCategory(IssueCategory *IssueCategory) *rmapi.IssueCategory {
-	apiIssueCategory := &rmapi.IssueCategory{
-		Project: rmapi.IdName{Id: IssueCategory.ProjectID},
-		Name:    IssueCategory.Name,
-	}
-
-	if IssueCategory.ID != "" {
-		apiIssueCategory.Id, _ = strconv.Atoi(IssueCategory.ID)
+func wrapIssueCategory(IssueCategory *IssueCategory) *apiIssueCategory {
+	return &apiIssueCategory{
+		Name:         IssueCategory.Name,
+		AssignedToID: nullableID(IssueCategory.AssignedToID),
 	}
-
-	return apiIssueCategory
 }
 
-func unwrapIssueCategory(apiIssueCategory *rmapi.IssueCategory) *IssueCategory {
+func unwrapIssueCategory(apiIssueCategory *apiIssueCategory) *IssueCategory {
 	IssueCategory := &IssueCategory{
-		ID:        strconv.Itoa(apiIssueCategory.Id),
-		Name:      apiIssueCategory.Name,
-		ProjectID: apiIssueCategory.Project.Id,
+		ID:   strconv.Itoa(apiIssueCategory.ID),
+		Name: apiIssueCategory.Name,
+	}
+
+	if apiIssueCategory.Project != nil {
+		IssueCategory.ProjectID = apiIssueCategory.Project.Id
+	}
+	if apiIssueCategory.AssignedTo != nil {
+		IssueCategory.AssignedToID = apiIssueCategory.AssignedTo.Id
 	}
 
 	return IssueCategory
@@ -0,0 +1,62 @@
+package redmine
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_UpdateIssueCategory(t *testing.T) {
+	t.Run("should send the assignee", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/issue_categories/3.json", r.URL.Path)
+			body, _ := ioutil.ReadAll(r.Body)
+			assert.JSONEq(t, `{"issue_category":{"name":"Backend","assigned_to_id":5}}`, string(body))
+
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+		sut, err := NewClient(Config{URL: server.URL, APIKey: "secret-key"})
+		require.NoError(t, err)
+
+		_, err = sut.UpdateIssueCategory(context.Background(), &IssueCategory{ID: "3", ProjectID: 1, Name: "Backend", AssignedToID: 5})
+
+		require.NoError(t, err)
+	})
+	t.Run("should send an empty string to remove the assignee", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := ioutil.ReadAll(r.Body)
+			assert.JSONEq(t, `{"issue_category":{"name":"Backend","assigned_to_id":""}}`, string(body))
+
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+		sut, err := NewClient(Config{URL: server.URL, APIKey: "secret-key"})
+		require.NoError(t, err)
+
+		_, err = sut.UpdateIssueCategory(context.Background(), &IssueCategory{ID: "3", ProjectID: 1, Name: "Backend"})
+
+		require.NoError(t, err)
+	})
+}
+
+func TestClient_ReadIssueCategory(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/issue_categories/3.json", r.URL.Path)
+		_, _ = w.Write([]byte(`{"issue_category":{"id":3,"project":{"id":1,"name":"Platform"},"name":"Backend",
+			"assigned_to":{"id":5,"name":"Backend team"}}}`))
+	}))
+	defer server.Close()
+	sut, err := NewClient(Config{URL: server.URL, APIKey: "secret-key"})
+	require.NoError(t, err)
+
+	category, err := sut.ReadIssueCategory(context.Background(), "3")
+
+	require.NoError(t, err)
+	assert.Equal(t, &IssueCategory{ID: "3", ProjectID: 1, Name: "Backend", AssignedToID: 5}, category)
+}
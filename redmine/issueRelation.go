@@ -0,0 +1,143 @@
+package redmine
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"net/http"
+	"strconv"
+)
+
+// IssueRelation links two issues, f. i. an issue that blocks or precedes another one. Relations cannot be changed once
+// they are created.
+type IssueRelation struct {
+	ID           string `json:"id"`
+	IssueID      int    `json:"issue_id"`
+	IssueToID    int    `json:"issue_to_id"`
+	RelationType string `json:"relation_type"`
+	// Delay contains the number of days between the end of the preceding issue and the start of the following issue.
+	// It is only used by the relation types precedes and follows.
+	Delay int `json:"delay"`
+}
+
+func (r *IssueRelation) String() string {
+	return fmt.Sprintf("IssueRelation{ID=%s,IssueID=%d,IssueToID=%d,RelationType=%s,Delay=%d}",
+		r.ID, r.IssueID, r.IssueToID, r.RelationType, r.Delay)
+}
+
+// reverseRelationTypes maps each relation type to the type that describes the same relation from the other issue's
+// point of view. Redmine stores relations like follows or blocked as their reverse type with swapped issues.
+var reverseRelationTypes = map[string]string{
+	"relates":     "relates",
+	"duplicates":  "duplicated",
+	"duplicated":  "duplicates",
+	"blocks":      "blocked",
+	"blocked":     "blocks",
+	"precedes":    "follows",
+	"follows":     "precedes",
+	"copied_to":   "copied_from",
+	"copied_from": "copied_to",
+}
+
+// Reversed returns the same relation from the point of view of the related issue. It returns false if the relation
+// type is unknown.
+func (r *IssueRelation) Reversed() (*IssueRelation, bool) {
+	reverseType, ok := reverseRelationTypes[r.RelationType]
+	if !ok {
+		return nil, false
+	}
+
+	return &IssueRelation{
+		ID:           r.ID,
+		IssueID:      r.IssueToID,
+		IssueToID:    r.IssueID,
+		RelationType: reverseType,
+		Delay:        r.Delay,
+	}, true
+}
+
+// apiIssueRelation contains the JSON representation of an issue relation. Redmine takes the issue_id from the request
+// path and returns a delay of null for relation types without delay.
+type apiIssueRelation struct {
+	ID           int    `json:"id,omitempty"`
+	IssueID      int    `json:"issue_id,omitempty"`
+	IssueToID    int    `json:"issue_to_id"`
+	RelationType string `json:"relation_type"`
+	Delay        *int   `json:"delay,omitempty"`
+}
+
+type issueRelationEnvelope struct {
+	Relation apiIssueRelation `json:"relation"`
+}
+
+func (c *Client) CreateIssueRelation(ctx context.Context, relation *IssueRelation) (*IssueRelation, error) {
+	apiRelation := wrapIssueRelation(relation)
+
+	var response issueRelationEnvelope
+	path := fmt.Sprintf("/issues/%d/relations.json", relation.IssueID)
+	err := c.sendJSON(ctx, http.MethodPost, path, issueRelationEnvelope{Relation: *apiRelation}, &response)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while creating issue relation (issue id: %d, issue to id: %d, type: %s)",
+			relation.IssueID, relation.IssueToID, relation.RelationType)
+	}
+
+	return unwrapIssueRelation(&response.Relation), nil
+}
+
+func (c *Client) ReadIssueRelation(ctx context.Context, id string) (*IssueRelation, error) {
+	idInt, err := verifyIDtoInt(id)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read issue relation because of malformed input data")
+	}
+
+	var response issueRelationEnvelope
+	err = c.getJSON(ctx, fmt.Sprintf("/relations/%d.json", idInt), &response)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while reading issue relation (id: %d)", idInt)
+	}
+
+	return unwrapIssueRelation(&response.Relation), nil
+}
+
+func (c *Client) DeleteIssueRelation(ctx context.Context, id string) error {
+	idInt, err := verifyIDtoInt(id)
+	if err != nil {
+		return errors.Wrap(err, "could not delete issue relation because of malformed input data")
+	}
+
+	err = c.sendJSON(ctx, http.MethodDelete, fmt.Sprintf("/relations/%d.json", idInt), nil, nil)
+	if err != nil {
+		return errors.Wrapf(err, "error while deleting issue relation (id: %d)", idInt)
+	}
+
+	return nil
+}
+
+func wrapIssueRelation(relation *IssueRelation) *apiIssueRelation {
+	apiRelation := &apiIssueRelation{
+		IssueToID:    relation.IssueToID,
+		RelationType: relation.RelationType,
+	}
+
+	if relation.Delay != 0 {
+		delay := relation.Delay
+		apiRelation.Delay = &delay
+	}
+
+	return apiRelation
+}
+
+func unwrapIssueRelation(apiRelation *apiIssueRelation) *IssueRelation {
+	relation := &IssueRelation{
+		ID:           strconv.Itoa(apiRelation.ID),
+		IssueID:      apiRelation.IssueID,
+		IssueToID:    apiRelation.IssueToID,
+		RelationType: apiRelation.RelationType,
+	}
+
+	if apiRelation.Delay != nil {
+		relation.Delay = *apiRelation.Delay
+	}
+
+	return relation
+}
@@ -0,0 +1,68 @@
+package redmine
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_CreateIssueRelation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/issues/3/relations.json", r.URL.Path)
+		body, _ := ioutil.ReadAll(r.Body)
+		assert.JSONEq(t, `{"relation":{"issue_to_id":4,"relation_type":"follows","delay":2}}`, string(body))
+
+		w.WriteHeader(http.StatusCreated)
+		// Redmine stores follows as precedes with swapped issues
+		_, _ = w.Write([]byte(`{"relation":{"id":7,"issue_id":4,"issue_to_id":3,"relation_type":"precedes","delay":2}}`))
+	}))
+	defer server.Close()
+	sut, err := NewClient(Config{URL: server.URL, APIKey: "secret-key"})
+	require.NoError(t, err)
+
+	relation, err := sut.CreateIssueRelation(context.Background(),
+		&IssueRelation{IssueID: 3, IssueToID: 4, RelationType: "follows", Delay: 2})
+
+	require.NoError(t, err)
+	expected := &IssueRelation{ID: "7", IssueID: 4, IssueToID: 3, RelationType: "precedes", Delay: 2}
+	assert.Equal(t, expected, relation)
+}
+
+func TestClient_ReadIssueRelation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/relations/7.json", r.URL.Path)
+		_, _ = w.Write([]byte(`{"relation":{"id":7,"issue_id":3,"issue_to_id":4,"relation_type":"relates","delay":null}}`))
+	}))
+	defer server.Close()
+	sut, err := NewClient(Config{URL: server.URL, APIKey: "secret-key"})
+	require.NoError(t, err)
+
+	relation, err := sut.ReadIssueRelation(context.Background(), "7")
+
+	require.NoError(t, err)
+	expected := &IssueRelation{ID: "7", IssueID: 3, IssueToID: 4, RelationType: "relates"}
+	assert.Equal(t, expected, relation)
+}
+
+func TestIssueRelation_Reversed(t *testing.T) {
+	t.Run("should swap issues and type", func(t *testing.T) {
+		relation := &IssueRelation{ID: "7", IssueID: 4, IssueToID: 3, RelationType: "precedes", Delay: 2}
+
+		reversed, ok := relation.Reversed()
+
+		require.True(t, ok)
+		assert.Equal(t, &IssueRelation{ID: "7", IssueID: 3, IssueToID: 4, RelationType: "follows", Delay: 2}, reversed)
+	})
+	t.Run("should fail for unknown type", func(t *testing.T) {
+		relation := &IssueRelation{ID: "7", IssueID: 4, IssueToID: 3, RelationType: "unknown"}
+
+		_, ok := relation.Reversed()
+
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,48 @@
+package redmine
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"strconv"
+)
+
+type IssueStatus struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	IsClosed bool   `json:"is_closed"`
+}
+
+func (s *IssueStatus) String() string {
+	return fmt.Sprintf("IssueStatus{ID=%s,Name=%s,IsClosed=%t}", s.ID, s.Name, s.IsClosed)
+}
+
+type apiIssueStatus struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	IsClosed bool   `json:"is_closed"`
+}
+
+type issueStatusesResponse struct {
+	IssueStatuses []apiIssueStatus `json:"issue_statuses"`
+}
+
+// ReadIssueStatuses reads all issue statuses that are configured in Redmine.
+func (c *Client) ReadIssueStatuses(ctx context.Context) ([]*IssueStatus, error) {
+	var response issueStatusesResponse
+	err := c.getJSON(ctx, "/issue_statuses.json", &response)
+	if err != nil {
+		return nil, errors.Wrap(err, "error while reading issue statuses")
+	}
+
+	var statuses []*IssueStatus
+	for _, apiStatus := range response.IssueStatuses {
+		statuses = append(statuses, &IssueStatus{
+			ID:       strconv.Itoa(apiStatus.ID),
+			Name:     apiStatus.Name,
+			IsClosed: apiStatus.IsClosed,
+		})
+	}
+
+	return statuses, nil
+}
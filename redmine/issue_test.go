@@ -0,0 +1,173 @@
+package redmine
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_UpdateIssue(t *testing.T) {
+	t.Run("should send empty strings to remove references", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := ioutil.ReadAll(r.Body)
+			assert.JSONEq(t, `{"issue":{"id":3,"project_id":1,"tracker_id":2,"subject":"issue subject","description":"",
+				"parent_issue_id":"","category_id":"","assigned_to_id":"","fixed_version_id":"","due_date":"",
+				"done_ratio":0,"estimated_hours":null,"is_private":false}}`, string(body))
+
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+		sut, err := NewClient(Config{URL: server.URL, APIKey: "secret-key"})
+		require.NoError(t, err)
+
+		_, err = sut.UpdateIssue(context.Background(), &Issue{ID: "3", ProjectID: 1, TrackerID: 2, Subject: "issue subject"})
+
+		require.NoError(t, err)
+	})
+	t.Run("should send the parent issue id to move the issue", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := ioutil.ReadAll(r.Body)
+			assert.Contains(t, string(body), `"parent_issue_id":7`)
+
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+		sut, err := NewClient(Config{URL: server.URL, APIKey: "secret-key"})
+		require.NoError(t, err)
+
+		_, err = sut.UpdateIssue(context.Background(), &Issue{ID: "3", ProjectID: 1, TrackerID: 2, Subject: "issue subject",
+			ParentIssueID: 7})
+
+		require.NoError(t, err)
+	})
+	t.Run("should send an empty parent issue id to detach the issue", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := ioutil.ReadAll(r.Body)
+			assert.Contains(t, string(body), `"parent_issue_id":""`)
+
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+		sut, err := NewClient(Config{URL: server.URL, APIKey: "secret-key"})
+		require.NoError(t, err)
+
+		_, err = sut.UpdateIssue(context.Background(), &Issue{ID: "3", ProjectID: 1, TrackerID: 2, Subject: "issue subject",
+			ParentIssueID: 0})
+
+		require.NoError(t, err)
+	})
+	t.Run("should send single and multiple custom field values", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := ioutil.ReadAll(r.Body)
+			assert.JSONEq(t, `{"issue":{"id":3,"project_id":1,"tracker_id":2,"subject":"issue subject","description":"",
+				"parent_issue_id":"","category_id":"","assigned_to_id":"","fixed_version_id":"","due_date":"",
+				"done_ratio":0,"estimated_hours":null,"is_private":false,
+				"custom_fields":[{"id":1,"name":"","description":"","multiple":false,"value":"high"},
+				{"id":2,"name":"","description":"","multiple":true,"value":["linux","windows"]}]}}`,
+				string(body))
+
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+		sut, err := NewClient(Config{URL: server.URL, APIKey: "secret-key"})
+		require.NoError(t, err)
+		customFields := []CustomField{
+			{ID: 1, Values: []string{"high"}},
+			{ID: 2, Multiple: true, Values: []string{"linux", "windows"}},
+		}
+
+		_, err = sut.UpdateIssue(context.Background(), &Issue{ID: "3", ProjectID: 1, TrackerID: 2, Subject: "issue subject",
+			CustomFields: customFields})
+
+		require.NoError(t, err)
+	})
+	t.Run("should only add and remove changed watchers", func(t *testing.T) {
+		var requests []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := ioutil.ReadAll(r.Body)
+			requests = append(requests, r.Method+" "+r.URL.Path+" "+string(body))
+			if r.Method == http.MethodGet {
+				_, _ = w.Write([]byte(`{"issue":{"id":3,"watchers":[{"id":5,"name":"Jane Doe"},{"id":6,"name":"John Doe"}]}}`))
+				return
+			}
+			assert.NotContains(t, string(body), "watcher_user_ids")
+
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+		sut, err := NewClient(Config{URL: server.URL, APIKey: "secret-key"})
+		require.NoError(t, err)
+
+		_, err = sut.UpdateIssue(context.Background(), &Issue{ID: "3", ProjectID: 1, TrackerID: 2, Subject: "issue subject",
+			WatcherUserIDs: []int{5, 7}})
+
+		require.NoError(t, err)
+		require.Len(t, requests, 4)
+		assert.Equal(t, "GET /issues/3.json ", requests[1])
+		assert.Equal(t, `POST /issues/3/watchers.json {"user_id":7}`, requests[2])
+		assert.Equal(t, "DELETE /issues/3/watchers/6.json ", requests[3])
+	})
+	t.Run("should send notes as journal entry", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := ioutil.ReadAll(r.Body)
+			assert.Contains(t, string(body), `"notes":"changed by Terraform","private_notes":true`)
+
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+		sut, err := NewClient(Config{URL: server.URL, APIKey: "secret-key"})
+		require.NoError(t, err)
+
+		_, err = sut.UpdateIssue(context.Background(), &Issue{ID: "3", ProjectID: 1, TrackerID: 2, Subject: "issue subject",
+			Notes: "changed by Terraform", PrivateNotes: true})
+
+		require.NoError(t, err)
+	})
+}
+
+func TestClient_ReadIssue_journals(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"issue":{"id":3,"subject":"issue subject","journals":[{"id":11,
+			"user":{"id":1,"name":"Redmine Admin"},"notes":"changed by Terraform","created_on":"2021-06-02T10:00:00Z",
+			"private_notes":false,"details":[{"property":"attr","name":"subject","old_value":"old","new_value":"issue subject"}]}]}}`))
+	}))
+	defer server.Close()
+	sut, err := NewClient(Config{URL: server.URL, APIKey: "secret-key"})
+	require.NoError(t, err)
+
+	issue, err := sut.ReadIssue(context.Background(), "3")
+
+	require.NoError(t, err)
+	expected := []Journal{{ID: 11, UserID: 1, UserName: "Redmine Admin", Notes: "changed by Terraform",
+		CreatedOn: "2021-06-02T10:00:00Z"}}
+	assert.Equal(t, expected, issue.Journals)
+}
+
+func TestClient_ReadIssue(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"issue":{"id":3,"project":{"id":1},"tracker":{"id":2},"status":{"id":2},
+			"priority":{"id":4},"assigned_to":{"id":5},"fixed_version":{"id":6},"parent":{"id":7},
+			"subject":"issue subject","start_date":"2021-06-01","due_date":"2021-06-30","done_ratio":30,
+			"estimated_hours":4.5,"is_private":true,
+			"custom_fields":[{"id":1,"name":"Risk","value":"high"},{"id":2,"name":"Platforms","multiple":true,"value":["linux"]}]}}`))
+	}))
+	defer server.Close()
+	sut, err := NewClient(Config{URL: server.URL, APIKey: "secret-key"})
+	require.NoError(t, err)
+
+	issue, err := sut.ReadIssue(context.Background(), "3")
+
+	require.NoError(t, err)
+	expected := &Issue{ID: "3", ProjectID: 1, TrackerID: 2, StatusID: 2, PriorityID: 4, AssignedToID: 5,
+		FixedVersionID: 6, ParentIssueID: 7, Subject: "issue subject", StartDate: "2021-06-01", DueDate: "2021-06-30",
+		DoneRatio: 30, EstimatedHours: 4.5, IsPrivate: true, CustomFields: []CustomField{
+			{ID: 1, Name: "Risk", Values: []string{"high"}},
+			{ID: 2, Name: "Platforms", Multiple: true, Values: []string{"linux"}},
+		}}
+	assert.Equal(t, expected, issue)
+}
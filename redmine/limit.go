@@ -0,0 +1,125 @@
+package redmine
+
+import (
+	"context"
+	"io"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// rateLimiter is a token bucket which allows a given number of requests per second. Up to one second worth of unused
+// tokens is saved up so that short bursts are sent without delay.
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter(requestsPerSecond float64) *rateLimiter {
+	burst := math.Max(1, math.Floor(requestsPerSecond))
+	return &rateLimiter{rate: requestsPerSecond, burst: burst, tokens: burst, last: time.Now()}
+}
+
+// wait blocks until a token is available or the context is done. A request that waits reserves the next free token so
+// that concurrent requests are queued instead of competing for the same token.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+	l.tokens--
+	delay := time.Duration(-l.tokens / l.rate * float64(time.Second))
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		// return the reserved token so that the following requests do not wait for it
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// limitTransport limits the rate of requests and the number of requests that are in flight at the same time. A
+// request stays in flight until its response body is closed. Both limits are disabled if they are nil.
+type limitTransport struct {
+	limiter *rateLimiter
+	slots   chan struct{}
+	next    http.RoundTripper
+}
+
+func newLimitTransport(config Config, next http.RoundTripper) http.RoundTripper {
+	if config.MaxRequestsPerSecond <= 0 && config.MaxConcurrentRequests <= 0 {
+		return next
+	}
+
+	transport := &limitTransport{next: next}
+	if config.MaxRequestsPerSecond > 0 {
+		transport.limiter = newRateLimiter(config.MaxRequestsPerSecond)
+	}
+	if config.MaxConcurrentRequests > 0 {
+		transport.slots = make(chan struct{}, config.MaxConcurrentRequests)
+	}
+
+	return transport
+}
+
+func (t *limitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	if t.slots != nil {
+		select {
+		case t.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	if t.limiter != nil {
+		if err := t.limiter.wait(ctx); err != nil {
+			t.release()
+			return nil, err
+		}
+	}
+
+	res, err := t.next.RoundTrip(req)
+	if err != nil {
+		t.release()
+		return nil, err
+	}
+
+	res.Body = &releasingBody{ReadCloser: res.Body, release: t.release}
+	return res, nil
+}
+
+func (t *limitTransport) release() {
+	if t.slots != nil {
+		<-t.slots
+	}
+}
+
+// releasingBody frees the slot of its request once the response body is closed.
+type releasingBody struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
@@ -0,0 +1,77 @@
+package redmine
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_MaxConcurrentRequests(t *testing.T) {
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if current <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		_, _ = w.Write([]byte(testProjectJSON))
+	}))
+	defer server.Close()
+	sut, err := NewClient(Config{URL: server.URL, APIKey: "secret-key", MaxConcurrentRequests: 2})
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := sut.ReadProject(context.Background(), "1")
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(&maxInFlight))
+}
+
+func Test_rateLimiter(t *testing.T) {
+	t.Run("should delay requests that exceed the burst", func(t *testing.T) {
+		sut := newRateLimiter(20)
+		start := time.Now()
+
+		for i := 0; i < 25; i++ {
+			require.NoError(t, sut.wait(context.Background()))
+		}
+
+		// 20 requests are sent immediately, the other 5 with 50ms in between
+		assert.True(t, time.Since(start) >= 200*time.Millisecond, time.Since(start))
+	})
+	t.Run("should stop waiting when the context is done", func(t *testing.T) {
+		sut := newRateLimiter(0.1)
+		require.NoError(t, sut.wait(context.Background()))
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		err := sut.wait(ctx)
+
+		assert.Equal(t, context.DeadlineExceeded, err)
+	})
+}
+
+func TestNewClient_requestLimits(t *testing.T) {
+	_, err := NewClient(Config{URL: "http://localhost:3000", APIKey: "abc", MaxConcurrentRequests: -1})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid request limits")
+}
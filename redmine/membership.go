@@ -0,0 +1,192 @@
+package redmine
+
+import (
+	"context"
+	"fmt"
+	rmapi "github.com/cloudogu/go-redmine"
+	"github.com/pkg/errors"
+	"net/http"
+	"strconv"
+)
+
+// Membership assigns roles in a project to either a user or a group.
+type Membership struct {
+	ID        string `json:"id"`
+	ProjectID int    `json:"project_id"`
+	// UserID is set if the membership belongs to a user. Either UserID or GroupID is set.
+	UserID int `json:"user_id"`
+	// GroupID is set if the membership belongs to a group. Either UserID or GroupID is set.
+	GroupID int `json:"group_id"`
+	// RoleIDs contains the roles that were assigned to the membership directly.
+	RoleIDs []int `json:"role_ids"`
+	// InheritedRoleIDs contains the roles that the membership inherited from a parent project or a group. They cannot
+	// be changed with the membership.
+	InheritedRoleIDs []int `json:"inherited_role_ids"`
+}
+
+func (m *Membership) String() string {
+	return fmt.Sprintf("Membership{ID=%s,ProjectID=%d,UserID=%d,GroupID=%d,RoleIDs=%v,InheritedRoleIDs=%v}",
+		m.ID, m.ProjectID, m.UserID, m.GroupID, m.RoleIDs, m.InheritedRoleIDs)
+}
+
+// apiMembership contains the JSON representation of a membership as Redmine returns it.
+type apiMembership struct {
+	ID      int                 `json:"id"`
+	Project *rmapi.IdName       `json:"project"`
+	User    *rmapi.IdName       `json:"user"`
+	Group   *rmapi.IdName       `json:"group"`
+	Roles   []apiMembershipRole `json:"roles"`
+}
+
+type apiMembershipRole struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	Inherited bool   `json:"inherited"`
+}
+
+// apiMembershipRequest contains the JSON representation of a membership as Redmine expects it in requests. Redmine
+// expects both user and group IDs as user_id.
+type apiMembershipRequest struct {
+	UserID  int   `json:"user_id,omitempty"`
+	RoleIDs []int `json:"role_ids"`
+}
+
+type membershipEnvelope struct {
+	Membership apiMembership `json:"membership"`
+}
+
+type membershipRequestEnvelope struct {
+	Membership apiMembershipRequest `json:"membership"`
+}
+
+type membershipsResponse struct {
+	pagination
+	Memberships []apiMembership `json:"memberships"`
+}
+
+func (c *Client) CreateMembership(ctx context.Context, membership *Membership) (*Membership, error) {
+	apiRequest := apiMembershipRequest{UserID: membership.UserID, RoleIDs: nonNilInts(membership.RoleIDs)}
+	if membership.GroupID != 0 {
+		apiRequest.UserID = membership.GroupID
+	}
+
+	var response membershipEnvelope
+	path := fmt.Sprintf("/projects/%d/memberships.json", membership.ProjectID)
+	err := c.sendJSON(ctx, http.MethodPost, path, membershipRequestEnvelope{Membership: apiRequest}, &response)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while creating membership (project id: %d, principal id: %d)",
+			membership.ProjectID, apiRequest.UserID)
+	}
+
+	return unwrapMembership(&response.Membership), nil
+}
+
+func (c *Client) ReadMembership(ctx context.Context, id string) (*Membership, error) {
+	idInt, err := verifyIDtoInt(id)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read membership because of malformed input data")
+	}
+
+	var response membershipEnvelope
+	err = c.getJSON(ctx, fmt.Sprintf("/memberships/%d.json", idInt), &response)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while reading membership (id: %d)", idInt)
+	}
+
+	return unwrapMembership(&response.Membership), nil
+}
+
+// ReadProjectMemberships reads all memberships of a project including inherited ones.
+func (c *Client) ReadProjectMemberships(ctx context.Context, projectID string) ([]*Membership, error) {
+	idInt, err := verifyIDtoInt(projectID)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read project memberships because of malformed input data")
+	}
+
+	var memberships []*Membership
+	err = getAllPages(fmt.Sprintf("/projects/%d/memberships.json", idInt), func(pagePath string) (pagination, int, error) {
+		var response membershipsResponse
+		if err := c.getJSON(ctx, pagePath, &response); err != nil {
+			return pagination{}, 0, err
+		}
+
+		for i := range response.Memberships {
+			memberships = append(memberships, unwrapMembership(&response.Memberships[i]))
+		}
+
+		return response.pagination, len(response.Memberships), nil
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while reading memberships of project (id: %d)", idInt)
+	}
+
+	return memberships, nil
+}
+
+// UpdateMembership updates the directly assigned roles of an existing membership. Inherited roles are kept by Redmine.
+func (c *Client) UpdateMembership(ctx context.Context, membership *Membership) (*Membership, error) {
+	idInt, err := verifyIDtoInt(membership.ID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not update membership (id: %s) because of malformed input data", membership.ID)
+	}
+
+	apiRequest := apiMembershipRequest{RoleIDs: nonNilInts(membership.RoleIDs)}
+
+	path := fmt.Sprintf("/memberships/%d.json", idInt)
+	err = c.sendJSON(ctx, http.MethodPut, path, membershipRequestEnvelope{Membership: apiRequest}, nil)
+	if err != nil {
+		return membership, errors.Wrapf(err, "error while updating membership (id: %d)", idInt)
+	}
+
+	return membership, nil
+}
+
+func (c *Client) DeleteMembership(ctx context.Context, id string) error {
+	idInt, err := verifyIDtoInt(id)
+	if err != nil {
+		return errors.Wrap(err, "could not delete membership because of malformed input data")
+	}
+
+	err = c.sendJSON(ctx, http.MethodDelete, fmt.Sprintf("/memberships/%d.json", idInt), nil, nil)
+	if err != nil {
+		return errors.Wrapf(err, "error while deleting membership (id: %d)", idInt)
+	}
+
+	return nil
+}
+
+func unwrapMembership(apiMembership *apiMembership) *Membership {
+	membership := &Membership{}
+
+	if apiMembership.ID != 0 {
+		membership.ID = strconv.Itoa(apiMembership.ID)
+	}
+	if apiMembership.Project != nil {
+		membership.ProjectID = apiMembership.Project.Id
+	}
+	if apiMembership.User != nil {
+		membership.UserID = apiMembership.User.Id
+	}
+	if apiMembership.Group != nil {
+		membership.GroupID = apiMembership.Group.Id
+	}
+
+	for _, role := range apiMembership.Roles {
+		if role.Inherited {
+			membership.InheritedRoleIDs = append(membership.InheritedRoleIDs, role.ID)
+		} else {
+			membership.RoleIDs = append(membership.RoleIDs, role.ID)
+		}
+	}
+
+	return membership
+}
+
+// nonNilInts returns an empty slice instead of nil so that it is encoded as empty JSON array instead of null.
+func nonNilInts(values []int) []int {
+	if values == nil {
+		return []int{}
+	}
+
+	return values
+}
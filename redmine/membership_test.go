@@ -0,0 +1,43 @@
+package redmine
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_ReadProjectMemberships(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/projects/2/memberships.json", r.URL.Path)
+		assert.Equal(t, "100", r.URL.Query().Get("limit"))
+
+		switch offset := r.URL.Query().Get("offset"); offset {
+		case "0":
+			_, _ = w.Write([]byte(`{"memberships":[{"id":7,"project":{"id":2},"user":{"id":5},
+				"roles":[{"id":3,"name":"Manager","inherited":true},{"id":4,"name":"Developer"}]}],
+				"total_count":2,"offset":0,"limit":1}`))
+		case "1":
+			_, _ = w.Write([]byte(`{"memberships":[{"id":8,"project":{"id":2},"group":{"id":6},
+				"roles":[{"id":4,"name":"Developer"}]}],"total_count":2,"offset":1,"limit":1}`))
+		default:
+			assert.Fail(t, fmt.Sprintf("unexpected offset %s", offset))
+		}
+	}))
+	defer server.Close()
+	sut, err := NewClient(Config{URL: server.URL, APIKey: "secret-key"})
+	require.NoError(t, err)
+
+	memberships, err := sut.ReadProjectMemberships(context.Background(), "2")
+
+	require.NoError(t, err)
+	expected := []*Membership{
+		{ID: "7", ProjectID: 2, UserID: 5, RoleIDs: []int{4}, InheritedRoleIDs: []int{3}},
+		{ID: "8", ProjectID: 2, GroupID: 6, RoleIDs: []int{4}},
+	}
+	assert.Equal(t, expected, memberships)
+}
@@ -2,8 +2,11 @@ package redmine
 
 import (
 	"context"
+	"fmt"
 	rmapi "github.com/cloudogu/go-redmine"
 	"github.com/pkg/errors"
+	"net/http"
+	"net/url"
 	"strconv"
 )
 
@@ -18,17 +21,67 @@ type Project struct {
 	InheritMembers bool   `json:"inherit_members"`
 	CreatedOn      string `json:"created_on"`
 	UpdatedOn      string `json:"updated_on"`
+	// EnabledModuleNames contains the names of the project's enabled modules, f. i. "issue_tracking" or "wiki". A nil
+	// slice leaves the modules unchanged (or lets Redmine enable its default modules on creation) while an empty slice
+	// disables all modules.
+	EnabledModuleNames []string `json:"enabled_module_names"`
+	// TrackerIDs contains the IDs of the trackers that can be used in the project's issues. A nil slice leaves the
+	// trackers unchanged while an empty slice removes all trackers.
+	TrackerIDs []int `json:"tracker_ids"`
+	// IssueCustomFieldIDs contains the IDs of the issue custom fields that are enabled for the project. A nil slice
+	// leaves the custom fields unchanged while an empty slice disables all of them.
+	IssueCustomFieldIDs []int `json:"issue_custom_field_ids"`
+	// CustomFields contains the values of the project's custom fields. Custom fields that are not contained are left
+	// unchanged.
+	CustomFields []CustomField `json:"custom_fields"`
+}
+
+// projectIncludes lists the associations that are read together with a project.
+const projectIncludes = "enabled_modules,trackers,issue_custom_fields"
+
+// apiProject contains the JSON representation of a Redmine project. Redmine expects the parent, the enabled modules,
+// the trackers and the issue custom fields as IDs or names in requests but returns them as objects in responses.
+type apiProject struct {
+	ID                  int                  `json:"id,omitempty"`
+	Name                string               `json:"name"`
+	Identifier          string               `json:"identifier"`
+	Description         string               `json:"description"`
+	Homepage            string               `json:"homepage"`
+	IsPublic            bool                 `json:"is_public"`
+	InheritMembers      bool                 `json:"inherit_members"`
+	ParentID            int                  `json:"parent_id,omitempty"`
+	Parent              *rmapi.IdName        `json:"parent,omitempty"`
+	EnabledModuleNames  *[]string            `json:"enabled_module_names,omitempty"`
+	EnabledModules      []rmapi.IdName       `json:"enabled_modules,omitempty"`
+	TrackerIDs          *[]int               `json:"tracker_ids,omitempty"`
+	Trackers            []rmapi.IdName       `json:"trackers,omitempty"`
+	IssueCustomFieldIDs *[]int               `json:"issue_custom_field_ids,omitempty"`
+	IssueCustomFields   []rmapi.IdName       `json:"issue_custom_fields,omitempty"`
+	CreatedOn           string               `json:"created_on,omitempty"`
+	UpdatedOn           string               `json:"updated_on,omitempty"`
+	CustomFields        []*rmapi.CustomField `json:"custom_fields,omitempty"`
+}
+
+// projectEnvelope wraps a project into the JSON object that Redmine expects in requests and returns in responses.
+type projectEnvelope struct {
+	Project apiProject `json:"project"`
+}
+
+type projectsResponse struct {
+	pagination
+	Projects []apiProject `json:"projects"`
 }
 
 func (c *Client) CreateProject(ctx context.Context, project *Project) (*Project, error) {
 	apiProj := wrapProject(project)
 
-	actualAPIProject, err := c.redmineAPI.CreateProject(*apiProj)
+	var response projectEnvelope
+	err := c.sendJSON(ctx, http.MethodPost, "/projects.json", projectEnvelope{Project: *apiProj}, &response)
 	if err != nil {
 		return nil, errors.Wrapf(err, "error while creating project (identifier: %s)", project.Identifier)
 	}
 
-	actualProject := unwrapProject(actualAPIProject)
+	actualProject := unwrapProject(&response.Project)
 
 	return actualProject, nil
 }
@@ -39,20 +92,65 @@ func (c *Client) ReadProject(ctx context.Context, id string) (project *Project,
 		return nil, errors.Wrap(err, "could not read project because of malformed input data")
 	}
 
-	apiProj, err := c.redmineAPI.Project(idInt)
+	var response projectEnvelope
+	err = c.getJSON(ctx, fmt.Sprintf("/projects/%d.json?include=%s", idInt, projectIncludes), &response)
 	if err != nil {
 		return project, errors.Wrapf(err, "error while reading project (id: %d)", idInt)
 	}
 
-	project = unwrapProject(apiProj)
+	project = unwrapProject(&response.Project)
 
 	return project, nil
 }
 
+// ReadProjectByIdentifier reads a project by its human-readable identifier instead of its numeric ID.
+func (c *Client) ReadProjectByIdentifier(ctx context.Context, identifier string) (project *Project, err error) {
+	if identifier == "" {
+		return nil, errors.New("could not read project because of malformed input data: identifier must not be empty")
+	}
+
+	var response projectEnvelope
+	err = c.getJSON(ctx, fmt.Sprintf("/projects/%s.json?include=%s", url.PathEscape(identifier), projectIncludes), &response)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while reading project (identifier: %s)", identifier)
+	}
+
+	return unwrapProject(&response.Project), nil
+}
+
+// ReadProjects reads all projects that are visible to the authenticated user. The projects do not contain enabled
+// modules, trackers and issue custom fields.
+func (c *Client) ReadProjects(ctx context.Context) ([]*Project, error) {
+	var projects []*Project
+	err := getAllPages("/projects.json", func(pagePath string) (pagination, int, error) {
+		var response projectsResponse
+		if err := c.getJSON(ctx, pagePath, &response); err != nil {
+			return pagination{}, 0, err
+		}
+
+		for i := range response.Projects {
+			projects = append(projects, unwrapProject(&response.Projects[i]))
+		}
+
+		return response.pagination, len(response.Projects), nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "error while reading projects")
+	}
+
+	return projects, nil
+}
+
 func (c *Client) UpdateProject(ctx context.Context, project *Project) (updatedProject *Project, err error) {
+	idInt, err := verifyIDtoInt(project.ID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not update project (id: %s, identifier: %s) because of malformed input data",
+			project.ID, project.Identifier)
+	}
+
 	apiProj := *wrapProject(project)
 
-	err = c.redmineAPI.UpdateProject(apiProj)
+	err = c.sendJSON(ctx, http.MethodPut, fmt.Sprintf("/projects/%d.json", idInt), projectEnvelope{Project: apiProj}, nil)
 	if err != nil {
 		return project, errors.Wrapf(err, "error while updating project (id: %s, identifier: %s)", project.ID, project.Identifier)
 	}
@@ -68,7 +166,7 @@ func (c *Client) DeleteProject(ctx context.Context, id string) error {
 		return errors.Wrap(err, "could not delete project because of malformed input data")
 	}
 
-	err = c.redmineAPI.DeleteProject(idInt)
+	err = c.sendJSON(ctx, http.MethodDelete, fmt.Sprintf("/projects/%d.json", idInt), nil, nil)
 	if err != nil {
 		return errors.Wrapf(err, "error while deleteting project (id: %d)", idInt)
 	}
@@ -77,8 +175,8 @@ func (c *Client) DeleteProject(ctx context.Context, id string) error {
 
 }
 
-func wrapProject(project *Project) *rmapi.Project {
-	apiProj := &rmapi.Project{
+func wrapProject(project *Project) *apiProject {
+	apiProj := &apiProject{
 		Name:           project.Name,
 		Identifier:     project.Identifier,
 		Description:    project.Description,
@@ -87,19 +185,32 @@ func wrapProject(project *Project) *rmapi.Project {
 		InheritMembers: project.InheritMembers,
 		CreatedOn:      project.CreatedOn,
 		UpdatedOn:      project.UpdatedOn,
+		CustomFields:   wrapCustomFields(project.CustomFields),
 	}
 
 	if project.ID != "" && project.ID != "0" {
-		apiProj.Id, _ = strconv.Atoi(project.ID)
+		apiProj.ID, _ = strconv.Atoi(project.ID)
 	}
 	if project.ParentID != "" {
-		apiProj.ParentID.Id, _ = strconv.Atoi(project.ParentID)
+		apiProj.ParentID, _ = strconv.Atoi(project.ParentID)
+	}
+	if project.EnabledModuleNames != nil {
+		moduleNames := append([]string{}, project.EnabledModuleNames...)
+		apiProj.EnabledModuleNames = &moduleNames
+	}
+	if project.TrackerIDs != nil {
+		trackerIDs := append([]int{}, project.TrackerIDs...)
+		apiProj.TrackerIDs = &trackerIDs
+	}
+	if project.IssueCustomFieldIDs != nil {
+		customFieldIDs := append([]int{}, project.IssueCustomFieldIDs...)
+		apiProj.IssueCustomFieldIDs = &customFieldIDs
 	}
 
 	return apiProj
 }
 
-func unwrapProject(apiProj *rmapi.Project) *Project {
+func unwrapProject(apiProj *apiProject) *Project {
 	project := &Project{
 		Name:           apiProj.Name,
 		Identifier:     apiProj.Identifier,
@@ -109,14 +220,46 @@ func unwrapProject(apiProj *rmapi.Project) *Project {
 		InheritMembers: apiProj.InheritMembers,
 		CreatedOn:      apiProj.CreatedOn,
 		UpdatedOn:      apiProj.UpdatedOn,
+		CustomFields:   unwrapCustomFields(apiProj.CustomFields),
 	}
 
-	if apiProj.Id != 0 {
-		project.ID = strconv.Itoa(apiProj.Id)
+	if apiProj.ID != 0 {
+		project.ID = strconv.Itoa(apiProj.ID)
 	}
-	if apiProj.ParentID.Id != 0 {
-		project.ParentID = strconv.Itoa(apiProj.ParentID.Id)
+	if apiProj.Parent != nil && apiProj.Parent.Id != 0 {
+		project.ParentID = strconv.Itoa(apiProj.Parent.Id)
+	} else if apiProj.ParentID != 0 {
+		project.ParentID = strconv.Itoa(apiProj.ParentID)
+	}
+
+	// responses contain the associations as objects while requests only contain their names or IDs
+	if apiProj.EnabledModules != nil {
+		project.EnabledModuleNames = []string{}
+		for _, module := range apiProj.EnabledModules {
+			project.EnabledModuleNames = append(project.EnabledModuleNames, module.Name)
+		}
+	} else if apiProj.EnabledModuleNames != nil {
+		project.EnabledModuleNames = append([]string{}, *apiProj.EnabledModuleNames...)
+	}
+	if apiProj.Trackers != nil {
+		project.TrackerIDs = idsOf(apiProj.Trackers)
+	} else if apiProj.TrackerIDs != nil {
+		project.TrackerIDs = append([]int{}, *apiProj.TrackerIDs...)
+	}
+	if apiProj.IssueCustomFields != nil {
+		project.IssueCustomFieldIDs = idsOf(apiProj.IssueCustomFields)
+	} else if apiProj.IssueCustomFieldIDs != nil {
+		project.IssueCustomFieldIDs = append([]int{}, *apiProj.IssueCustomFieldIDs...)
 	}
 
 	return project
 }
+
+func idsOf(idNames []rmapi.IdName) []int {
+	ids := []int{}
+	for _, idName := range idNames {
+		ids = append(ids, idName.Id)
+	}
+
+	return ids
+}
@@ -0,0 +1,159 @@
+package redmine
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures how often and how long the client retries requests that failed transiently, f. i. because a
+// reverse proxy in front of Redmine returned HTTP 503 during a deployment.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts per request including the first one. 1 disables retries.
+	MaxAttempts int
+	// MinBackoff is the wait time before the first retry. It doubles with every further retry.
+	MinBackoff time.Duration
+	// MaxBackoff limits the wait time between two attempts, also if Redmine asks for a longer one with Retry-After.
+	MaxBackoff time.Duration
+	// RetryNonIdempotent enables retries of POST and PATCH requests. They may create entities twice if Redmine
+	// processed a request but its response got lost.
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy is used if the client configuration does not contain a retry policy.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	MinBackoff:  time.Second,
+	MaxBackoff:  30 * time.Second,
+}
+
+// retryableStatusCodes contains the HTTP status codes of responses that are worth another attempt.
+var retryableStatusCodes = map[int]bool{
+	http.StatusTooManyRequests:    true,
+	http.StatusBadGateway:         true,
+	http.StatusServiceUnavailable: true,
+	http.StatusGatewayTimeout:     true,
+}
+
+func (p RetryPolicy) validate() error {
+	if p.MaxAttempts < 1 {
+		return errors.Errorf("invalid retry policy: max attempts must be at least 1 but is %d", p.MaxAttempts)
+	}
+	if p.MinBackoff < 0 || p.MaxBackoff < p.MinBackoff {
+		return errors.Errorf("invalid retry policy: backoff must be between 0 and %s but starts at %s",
+			p.MaxBackoff, p.MinBackoff)
+	}
+
+	return nil
+}
+
+// backoff returns the wait time before the given retry (starting with 1). The wait time grows exponentially and is
+// randomized between its half and its full length so that several clients do not retry at the same time.
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	backoff := p.MinBackoff
+	for i := 1; i < retry && backoff < p.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > p.MaxBackoff {
+		backoff = p.MaxBackoff
+	}
+	if backoff <= 1 {
+		return backoff
+	}
+
+	return backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)))
+}
+
+func (p RetryPolicy) isRetryableMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return p.RetryNonIdempotent
+	}
+}
+
+// retryTransport sends requests again that failed because of network errors or transient HTTP status codes. A
+// Retry-After header of the response takes precedence over the policy's backoff but is capped at its MaxBackoff.
+type retryTransport struct {
+	policy RetryPolicy
+	next   http.RoundTripper
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// streamed bodies like uploaded files cannot be sent again
+	retryable := t.policy.isRetryableMethod(req.Method) && (req.Body == nil || req.GetBody != nil)
+
+	for attempt := 1; ; attempt++ {
+		attemptReq := req
+		if attempt > 1 && req.Body != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, errors.Wrapf(err, "could not repeat %s request body", req.Method)
+			}
+			attemptReq = req.Clone(req.Context())
+			attemptReq.Body = body
+		}
+
+		res, err := t.next.RoundTrip(attemptReq)
+		if !retryable || attempt >= t.policy.MaxAttempts || !shouldRetry(req.Context(), res, err) {
+			return res, err
+		}
+
+		wait := t.policy.backoff(attempt)
+		if res != nil {
+			if retryAfter, ok := parseRetryAfter(res.Header.Get("Retry-After")); ok {
+				wait = retryAfter
+				if wait > t.policy.MaxBackoff {
+					wait = t.policy.MaxBackoff
+				}
+			}
+			// the connection can only be reused if the body was read completely
+			_, _ = io.Copy(ioutil.Discard, res.Body)
+			_ = res.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func shouldRetry(ctx context.Context, res *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		return true
+	}
+
+	return retryableStatusCodes[res.StatusCode]
+}
+
+// parseRetryAfter parses the Retry-After header which contains either the seconds to wait or an HTTP date.
+func parseRetryAfter(header string) (time.Duration, bool) {
+	if header == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(header); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
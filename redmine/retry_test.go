@@ -0,0 +1,196 @@
+package redmine
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testRetryPolicy = RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+
+// newFlakyServer returns a server that responds with the given status code to the first failures requests and
+// otherwise with a project.
+func newFlakyServer(t *testing.T, failures, statusCode int, requests *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		*requests = append(*requests, r.Method+" "+string(body))
+
+		if len(*requests) <= failures {
+			w.WriteHeader(statusCode)
+			return
+		}
+		_, _ = w.Write([]byte(testProjectJSON))
+	}))
+}
+
+func TestClient_retry(t *testing.T) {
+	t.Run("should retry GET requests on transient errors", func(t *testing.T) {
+		var requests []string
+		server := newFlakyServer(t, 2, http.StatusServiceUnavailable, &requests)
+		defer server.Close()
+		sut, err := NewClient(Config{URL: server.URL, APIKey: "secret-key", Retry: testRetryPolicy})
+		require.NoError(t, err)
+
+		project, err := sut.ReadProject(context.Background(), "1")
+
+		require.NoError(t, err)
+		assert.Equal(t, "Example", project.Name)
+		assert.Len(t, requests, 3)
+	})
+	t.Run("should send the body of PUT requests again", func(t *testing.T) {
+		var requests []string
+		server := newFlakyServer(t, 1, http.StatusBadGateway, &requests)
+		defer server.Close()
+		sut, err := NewClient(Config{URL: server.URL, APIKey: "secret-key", Retry: testRetryPolicy})
+		require.NoError(t, err)
+
+		err = sut.sendJSON(context.Background(), http.MethodPut, "/projects/1.json", map[string]string{"name": "Example"}, nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{`PUT {"name":"Example"}`, `PUT {"name":"Example"}`}, requests)
+	})
+	t.Run("should give up after the max attempts", func(t *testing.T) {
+		var requests []string
+		server := newFlakyServer(t, 5, http.StatusGatewayTimeout, &requests)
+		defer server.Close()
+		sut, err := NewClient(Config{URL: server.URL, APIKey: "secret-key", Retry: testRetryPolicy})
+		require.NoError(t, err)
+
+		_, err = sut.ReadProject(context.Background(), "1")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed with HTTP status 504")
+		assert.Len(t, requests, 3)
+	})
+	t.Run("should not retry other errors", func(t *testing.T) {
+		var requests []string
+		server := newFlakyServer(t, 1, http.StatusInternalServerError, &requests)
+		defer server.Close()
+		sut, err := NewClient(Config{URL: server.URL, APIKey: "secret-key", Retry: testRetryPolicy})
+		require.NoError(t, err)
+
+		_, err = sut.ReadProject(context.Background(), "1")
+
+		require.Error(t, err)
+		assert.Len(t, requests, 1)
+	})
+	t.Run("should not retry POST requests by default", func(t *testing.T) {
+		var requests []string
+		server := newFlakyServer(t, 1, http.StatusServiceUnavailable, &requests)
+		defer server.Close()
+		sut, err := NewClient(Config{URL: server.URL, APIKey: "secret-key", Retry: testRetryPolicy})
+		require.NoError(t, err)
+
+		_, err = sut.CreateProject(context.Background(), &Project{Identifier: "example", Name: "Example"})
+
+		require.Error(t, err)
+		assert.Len(t, requests, 1)
+	})
+	t.Run("should retry POST requests if enabled", func(t *testing.T) {
+		var requests []string
+		server := newFlakyServer(t, 1, http.StatusServiceUnavailable, &requests)
+		defer server.Close()
+		policy := testRetryPolicy
+		policy.RetryNonIdempotent = true
+		sut, err := NewClient(Config{URL: server.URL, APIKey: "secret-key", Retry: policy})
+		require.NoError(t, err)
+
+		_, err = sut.CreateProject(context.Background(), &Project{Identifier: "example", Name: "Example"})
+
+		require.NoError(t, err)
+		assert.Len(t, requests, 2)
+	})
+	t.Run("should honour Retry-After", func(t *testing.T) {
+		requests := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			if requests == 1 {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			_, _ = w.Write([]byte(testProjectJSON))
+		}))
+		defer server.Close()
+		// the backoff would exceed the test's deadline
+		sut, err := NewClient(Config{URL: server.URL, APIKey: "secret-key",
+			Retry: RetryPolicy{MaxAttempts: 2, MinBackoff: time.Hour, MaxBackoff: time.Hour}})
+		require.NoError(t, err)
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		_, err = sut.ReadProject(ctx, "1")
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, requests)
+	})
+	t.Run("should cap Retry-After at the max backoff", func(t *testing.T) {
+		requests := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			if requests == 1 {
+				w.Header().Set("Retry-After", "3600")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			_, _ = w.Write([]byte(testProjectJSON))
+		}))
+		defer server.Close()
+		sut, err := NewClient(Config{URL: server.URL, APIKey: "secret-key",
+			Retry: RetryPolicy{MaxAttempts: 2, MinBackoff: time.Millisecond, MaxBackoff: 10 * time.Millisecond}})
+		require.NoError(t, err)
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		_, err = sut.ReadProject(ctx, "1")
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, requests)
+	})
+	t.Run("should stop waiting when the context is done", func(t *testing.T) {
+		var requests []string
+		server := newFlakyServer(t, 1, http.StatusServiceUnavailable, &requests)
+		defer server.Close()
+		sut, err := NewClient(Config{URL: server.URL, APIKey: "secret-key",
+			Retry: RetryPolicy{MaxAttempts: 2, MinBackoff: time.Hour, MaxBackoff: time.Hour}})
+		require.NoError(t, err)
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		_, err = sut.ReadProject(ctx, "1")
+
+		require.Error(t, err)
+		assert.Len(t, requests, 1)
+	})
+}
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	sut := RetryPolicy{MaxAttempts: 5, MinBackoff: time.Second, MaxBackoff: 3 * time.Second}
+
+	assert.InDelta(t, 0.75*float64(time.Second), float64(sut.backoff(1)), 0.25*float64(time.Second))
+	assert.InDelta(t, 1.5*float64(time.Second), float64(sut.backoff(2)), 0.5*float64(time.Second))
+	assert.InDelta(t, 2.25*float64(time.Second), float64(sut.backoff(3)), 0.75*float64(time.Second))
+	assert.InDelta(t, 2.25*float64(time.Second), float64(sut.backoff(10)), 0.75*float64(time.Second))
+}
+
+func Test_parseRetryAfter(t *testing.T) {
+	wait, ok := parseRetryAfter("120")
+	assert.True(t, ok)
+	assert.Equal(t, 2*time.Minute, wait)
+
+	wait, ok = parseRetryAfter(time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+	assert.True(t, ok)
+	assert.InDelta(t, float64(time.Hour), float64(wait), float64(2*time.Second))
+
+	_, ok = parseRetryAfter("")
+	assert.False(t, ok)
+	_, ok = parseRetryAfter("soon")
+	assert.False(t, ok)
+}
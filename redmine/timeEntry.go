@@ -0,0 +1,236 @@
+package redmine
+
+import (
+	"context"
+	"fmt"
+	rmapi "github.com/cloudogu/go-redmine"
+	"github.com/pkg/errors"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// TimeEntry contains the hours that a user spent on a project or an issue at a day.
+type TimeEntry struct {
+	ID string `json:"id"`
+	// ProjectID is derived from the issue if IssueID is set.
+	ProjectID int `json:"project_id"`
+	// IssueID is 0 if the time was spent on the project itself.
+	IssueID int `json:"issue_id"`
+	// UserID defaults to the authenticated user if it is 0.
+	UserID int `json:"user_id"`
+	// SpentOn contains the date in the format YYYY-MM-DD. It defaults to the current date if it is empty.
+	SpentOn string  `json:"spent_on"`
+	Hours   float64 `json:"hours"`
+	// ActivityID defaults to Redmine's default time entry activity if it is 0.
+	ActivityID int    `json:"activity_id"`
+	Comments   string `json:"comments"`
+	CreatedOn  string `json:"created_on"`
+	UpdatedOn  string `json:"updated_on"`
+	// CustomFields contains the values of the time entry's custom fields. Custom fields that are not contained are
+	// left unchanged.
+	CustomFields []CustomField `json:"custom_fields"`
+}
+
+func (t *TimeEntry) String() string {
+	return fmt.Sprintf("TimeEntry{ID=%s,ProjectID=%d,IssueID=%d,UserID=%d,SpentOn=%s,Hours=%g,ActivityID=%d,Comments=%s}",
+		t.ID, t.ProjectID, t.IssueID, t.UserID, t.SpentOn, t.Hours, t.ActivityID, t.Comments)
+}
+
+// TimeEntryFilter restricts the time entries that are read. Zero values do not restrict the time entries.
+type TimeEntryFilter struct {
+	// ProjectID restricts the time entries to a project and its subprojects.
+	ProjectID  int
+	UserID     int
+	ActivityID int
+	// From contains the first day of the time entries in the format YYYY-MM-DD.
+	From string
+	// To contains the last day of the time entries in the format YYYY-MM-DD.
+	To string
+}
+
+func (f TimeEntryFilter) query() url.Values {
+	query := url.Values{}
+	if f.ProjectID != 0 {
+		query.Set("project_id", strconv.Itoa(f.ProjectID))
+	}
+	if f.UserID != 0 {
+		query.Set("user_id", strconv.Itoa(f.UserID))
+	}
+	if f.ActivityID != 0 {
+		query.Set("activity_id", strconv.Itoa(f.ActivityID))
+	}
+	if f.From != "" {
+		query.Set("from", f.From)
+	}
+	if f.To != "" {
+		query.Set("to", f.To)
+	}
+
+	return query
+}
+
+// apiTimeEntry contains the JSON representation of a time entry. Redmine expects the references as IDs in requests
+// but returns them as objects in responses.
+type apiTimeEntry struct {
+	ID           int                  `json:"id,omitempty"`
+	ProjectID    int                  `json:"project_id,omitempty"`
+	Project      *rmapi.IdName        `json:"project,omitempty"`
+	IssueID      nullableID           `json:"issue_id"`
+	Issue        *rmapi.Id            `json:"issue,omitempty"`
+	UserID       int                  `json:"user_id,omitempty"`
+	User         *rmapi.IdName        `json:"user,omitempty"`
+	ActivityID   int                  `json:"activity_id,omitempty"`
+	Activity     *rmapi.IdName        `json:"activity,omitempty"`
+	SpentOn      string               `json:"spent_on,omitempty"`
+	Hours        float64              `json:"hours"`
+	Comments     string               `json:"comments"`
+	CreatedOn    string               `json:"created_on,omitempty"`
+	UpdatedOn    string               `json:"updated_on,omitempty"`
+	CustomFields []*rmapi.CustomField `json:"custom_fields,omitempty"`
+}
+
+type timeEntryEnvelope struct {
+	TimeEntry apiTimeEntry `json:"time_entry"`
+}
+
+type timeEntriesResponse struct {
+	pagination
+	TimeEntries []apiTimeEntry `json:"time_entries"`
+}
+
+func (c *Client) CreateTimeEntry(ctx context.Context, timeEntry *TimeEntry) (*TimeEntry, error) {
+	apiEntry := wrapTimeEntry(timeEntry)
+
+	var response timeEntryEnvelope
+	err := c.sendJSON(ctx, http.MethodPost, "/time_entries.json", timeEntryEnvelope{TimeEntry: *apiEntry}, &response)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while creating time entry (project id: %d, issue id: %d)",
+			timeEntry.ProjectID, timeEntry.IssueID)
+	}
+
+	return unwrapTimeEntry(&response.TimeEntry), nil
+}
+
+func (c *Client) ReadTimeEntry(ctx context.Context, id string) (*TimeEntry, error) {
+	idInt, err := verifyIDtoInt(id)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read time entry because of malformed input data")
+	}
+
+	var response timeEntryEnvelope
+	err = c.getJSON(ctx, fmt.Sprintf("/time_entries/%d.json", idInt), &response)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while reading time entry (id: %d)", idInt)
+	}
+
+	return unwrapTimeEntry(&response.TimeEntry), nil
+}
+
+// ReadTimeEntries reads all time entries that match the filter and that are visible to the authenticated user.
+func (c *Client) ReadTimeEntries(ctx context.Context, filter TimeEntryFilter) ([]*TimeEntry, error) {
+	path := "/time_entries.json"
+	if query := filter.query().Encode(); query != "" {
+		path += "?" + query
+	}
+
+	var timeEntries []*TimeEntry
+	err := getAllPages(path, func(pagePath string) (pagination, int, error) {
+		var response timeEntriesResponse
+		if err := c.getJSON(ctx, pagePath, &response); err != nil {
+			return pagination{}, 0, err
+		}
+
+		for i := range response.TimeEntries {
+			timeEntries = append(timeEntries, unwrapTimeEntry(&response.TimeEntries[i]))
+		}
+
+		return response.pagination, len(response.TimeEntries), nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "error while reading time entries")
+	}
+
+	return timeEntries, nil
+}
+
+func (c *Client) UpdateTimeEntry(ctx context.Context, timeEntry *TimeEntry) (*TimeEntry, error) {
+	idInt, err := verifyIDtoInt(timeEntry.ID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not update time entry (id: %s) because of malformed input data", timeEntry.ID)
+	}
+
+	apiEntry := *wrapTimeEntry(timeEntry)
+
+	path := fmt.Sprintf("/time_entries/%d.json", idInt)
+	err = c.sendJSON(ctx, http.MethodPut, path, timeEntryEnvelope{TimeEntry: apiEntry}, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while updating time entry (id: %d)", idInt)
+	}
+
+	return timeEntry, nil
+}
+
+func (c *Client) DeleteTimeEntry(ctx context.Context, id string) error {
+	idInt, err := verifyIDtoInt(id)
+	if err != nil {
+		return errors.Wrap(err, "could not delete time entry because of malformed input data")
+	}
+
+	err = c.sendJSON(ctx, http.MethodDelete, fmt.Sprintf("/time_entries/%d.json", idInt), nil, nil)
+	if err != nil {
+		return errors.Wrapf(err, "error while deleting time entry (id: %d)", idInt)
+	}
+
+	return nil
+}
+
+func wrapTimeEntry(timeEntry *TimeEntry) *apiTimeEntry {
+	apiEntry := &apiTimeEntry{
+		ProjectID:    timeEntry.ProjectID,
+		IssueID:      nullableID(timeEntry.IssueID),
+		UserID:       timeEntry.UserID,
+		ActivityID:   timeEntry.ActivityID,
+		SpentOn:      timeEntry.SpentOn,
+		Hours:        timeEntry.Hours,
+		Comments:     timeEntry.Comments,
+		CustomFields: wrapCustomFields(timeEntry.CustomFields),
+	}
+
+	if timeEntry.ID != "" && timeEntry.ID != "0" {
+		apiEntry.ID, _ = strconv.Atoi(timeEntry.ID)
+	}
+
+	return apiEntry
+}
+
+func unwrapTimeEntry(apiEntry *apiTimeEntry) *TimeEntry {
+	timeEntry := &TimeEntry{
+		ID:           strconv.Itoa(apiEntry.ID),
+		ProjectID:    apiEntry.ProjectID,
+		IssueID:      int(apiEntry.IssueID),
+		UserID:       apiEntry.UserID,
+		ActivityID:   apiEntry.ActivityID,
+		SpentOn:      apiEntry.SpentOn,
+		Hours:        apiEntry.Hours,
+		Comments:     apiEntry.Comments,
+		CreatedOn:    apiEntry.CreatedOn,
+		UpdatedOn:    apiEntry.UpdatedOn,
+		CustomFields: unwrapCustomFields(apiEntry.CustomFields),
+	}
+
+	if apiEntry.Project != nil {
+		timeEntry.ProjectID = apiEntry.Project.Id
+	}
+	if apiEntry.Issue != nil {
+		timeEntry.IssueID = apiEntry.Issue.Id
+	}
+	if apiEntry.User != nil {
+		timeEntry.UserID = apiEntry.User.Id
+	}
+	if apiEntry.Activity != nil {
+		timeEntry.ActivityID = apiEntry.Activity.Id
+	}
+
+	return timeEntry
+}
@@ -0,0 +1,70 @@
+package redmine
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_ReadTimeEntries(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/time_entries.json", r.URL.Path)
+		query := r.URL.Query()
+		assert.Equal(t, "2", query.Get("project_id"))
+		assert.Equal(t, "2021-06-01", query.Get("from"))
+		assert.Equal(t, "2021-06-30", query.Get("to"))
+		assert.Empty(t, query.Get("user_id"))
+
+		switch offset := query.Get("offset"); offset {
+		case "0":
+			_, _ = w.Write([]byte(`{"time_entries":[{"id":7,"project":{"id":2},"issue":{"id":3},"user":{"id":5},
+				"activity":{"id":9},"hours":1.5,"comments":"on-call","spent_on":"2021-06-01"}],
+				"total_count":2,"offset":0,"limit":1}`))
+		case "1":
+			_, _ = w.Write([]byte(`{"time_entries":[{"id":8,"project":{"id":2},"user":{"id":5},"activity":{"id":9},
+				"hours":2,"comments":"","spent_on":"2021-06-02"}],"total_count":2,"offset":1,"limit":1}`))
+		default:
+			assert.Fail(t, fmt.Sprintf("unexpected offset %s", offset))
+		}
+	}))
+	defer server.Close()
+	sut, err := NewClient(Config{URL: server.URL, APIKey: "secret-key"})
+	require.NoError(t, err)
+
+	timeEntries, err := sut.ReadTimeEntries(context.Background(),
+		TimeEntryFilter{ProjectID: 2, From: "2021-06-01", To: "2021-06-30"})
+
+	require.NoError(t, err)
+	expected := []*TimeEntry{
+		{ID: "7", ProjectID: 2, IssueID: 3, UserID: 5, ActivityID: 9, Hours: 1.5, Comments: "on-call", SpentOn: "2021-06-01"},
+		{ID: "8", ProjectID: 2, UserID: 5, ActivityID: 9, Hours: 2, SpentOn: "2021-06-02"},
+	}
+	assert.Equal(t, expected, timeEntries)
+}
+
+func TestClient_UpdateTimeEntry(t *testing.T) {
+	t.Run("should send empty issue to book the time on the project", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/time_entries/7.json", r.URL.Path)
+			body, _ := ioutil.ReadAll(r.Body)
+			assert.JSONEq(t, `{"time_entry":{"id":7,"project_id":2,"issue_id":"","activity_id":9,
+				"spent_on":"2021-06-01","hours":1.5,"comments":"on-call"}}`, string(body))
+
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+		sut, err := NewClient(Config{URL: server.URL, APIKey: "secret-key"})
+		require.NoError(t, err)
+
+		_, err = sut.UpdateTimeEntry(context.Background(), &TimeEntry{ID: "7", ProjectID: 2, ActivityID: 9,
+			SpentOn: "2021-06-01", Hours: 1.5, Comments: "on-call"})
+
+		require.NoError(t, err)
+	})
+}
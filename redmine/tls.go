@@ -0,0 +1,49 @@
+package redmine
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"github.com/pkg/errors"
+)
+
+// TLSVersions maps the supported minimum TLS versions to their identifiers in crypto/tls.
+var TLSVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// newTLSConfig returns the TLS configuration for the connections to Redmine. Certificates of Redmine are verified
+// against the configured CA certificates in addition to the system's CA certificates. The client certificate is
+// presented to Redmine if it asks for one.
+func newTLSConfig(config Config) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: config.SkipCertVerify,
+		MinVersion:         config.MinTLSVersion,
+	}
+
+	if config.CACertPEM != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM([]byte(config.CACertPEM)) {
+			return nil, errors.New("invalid CA certificate: no PEM encoded certificate found")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if config.ClientCertPEM != "" || config.ClientKeyPEM != "" {
+		if config.ClientCertPEM == "" || config.ClientKeyPEM == "" {
+			return nil, errors.New("invalid client certificate: both certificate and key must be configured")
+		}
+		cert, err := tls.X509KeyPair([]byte(config.ClientCertPEM), []byte(config.ClientKeyPEM))
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid client certificate")
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
@@ -0,0 +1,138 @@
+package redmine
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTLSServer() *httptest.Server {
+	return httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(testProjectJSON))
+	}))
+}
+
+func serverCertPEM(server *httptest.Server) string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+}
+
+// newClientCertPEM creates a self-signed client certificate and returns it and its key PEM encoded.
+func newClientCertPEM(t *testing.T) (*x509.Certificate, string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "terraform"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(certDER)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return cert,
+		string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+}
+
+func TestClient_tls(t *testing.T) {
+	t.Run("should verify Redmine's certificate with the configured CA", func(t *testing.T) {
+		server := newTestTLSServer()
+		server.StartTLS()
+		defer server.Close()
+		sut, err := NewClient(Config{URL: server.URL, APIKey: "secret-key", CACertPEM: serverCertPEM(server)})
+		require.NoError(t, err)
+
+		_, err = sut.ReadProject(context.Background(), "1")
+
+		require.NoError(t, err)
+	})
+	t.Run("should fail for unknown CA", func(t *testing.T) {
+		server := newTestTLSServer()
+		server.StartTLS()
+		defer server.Close()
+		sut, err := NewClient(Config{URL: server.URL, APIKey: "secret-key", Retry: RetryPolicy{MaxAttempts: 1}})
+		require.NoError(t, err)
+
+		_, err = sut.ReadProject(context.Background(), "1")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "certificate")
+	})
+	t.Run("should present the client certificate", func(t *testing.T) {
+		clientCert, clientCertPEM, clientKeyPEM := newClientCertPEM(t)
+		server := newTestTLSServer()
+		server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: x509.NewCertPool()}
+		server.TLS.ClientCAs.AddCert(clientCert)
+		server.StartTLS()
+		defer server.Close()
+		sut, err := NewClient(Config{URL: server.URL, APIKey: "secret-key", CACertPEM: serverCertPEM(server),
+			ClientCertPEM: clientCertPEM, ClientKeyPEM: clientKeyPEM})
+		require.NoError(t, err)
+
+		_, err = sut.ReadProject(context.Background(), "1")
+
+		require.NoError(t, err)
+	})
+	t.Run("should fail below the minimum TLS version", func(t *testing.T) {
+		server := newTestTLSServer()
+		server.TLS = &tls.Config{MaxVersion: tls.VersionTLS12}
+		server.StartTLS()
+		defer server.Close()
+		sut, err := NewClient(Config{URL: server.URL, APIKey: "secret-key", CACertPEM: serverCertPEM(server),
+			MinTLSVersion: tls.VersionTLS13, Retry: RetryPolicy{MaxAttempts: 1}})
+		require.NoError(t, err)
+
+		_, err = sut.ReadProject(context.Background(), "1")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "protocol version")
+	})
+}
+
+func TestNewClient_tls(t *testing.T) {
+	t.Run("should fail for invalid CA certificate", func(t *testing.T) {
+		_, err := NewClient(Config{URL: "https://localhost", APIKey: "abc", CACertPEM: "no certificate"})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid CA certificate")
+	})
+	t.Run("should fail for client certificate without key", func(t *testing.T) {
+		_, clientCertPEM, _ := newClientCertPEM(t)
+
+		_, err := NewClient(Config{URL: "https://localhost", APIKey: "abc", ClientCertPEM: clientCertPEM})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "both certificate and key must be configured")
+	})
+	t.Run("should fail for mismatching client key", func(t *testing.T) {
+		_, clientCertPEM, _ := newClientCertPEM(t)
+		_, _, otherKeyPEM := newClientCertPEM(t)
+
+		_, err := NewClient(Config{URL: "https://localhost", APIKey: "abc", ClientCertPEM: clientCertPEM,
+			ClientKeyPEM: otherKeyPEM})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid client certificate")
+	})
+}
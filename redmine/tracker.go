@@ -0,0 +1,55 @@
+package redmine
+
+import (
+	"context"
+	"fmt"
+	rmapi "github.com/cloudogu/go-redmine"
+	"github.com/pkg/errors"
+	"strconv"
+)
+
+type Tracker struct {
+	ID              string `json:"id"`
+	Name            string `json:"name"`
+	Description     string `json:"description"`
+	DefaultStatusID int    `json:"default_status_id"`
+}
+
+func (t *Tracker) String() string {
+	return fmt.Sprintf("Tracker{ID=%s,Name=%s,Description=%s,DefaultStatusID=%d}", t.ID, t.Name, t.Description, t.DefaultStatusID)
+}
+
+type apiTracker struct {
+	ID            int           `json:"id"`
+	Name          string        `json:"name"`
+	Description   string        `json:"description"`
+	DefaultStatus *rmapi.IdName `json:"default_status"`
+}
+
+type trackersResponse struct {
+	Trackers []apiTracker `json:"trackers"`
+}
+
+// ReadTrackers reads all trackers that are configured in Redmine.
+func (c *Client) ReadTrackers(ctx context.Context) ([]*Tracker, error) {
+	var response trackersResponse
+	err := c.getJSON(ctx, "/trackers.json", &response)
+	if err != nil {
+		return nil, errors.Wrap(err, "error while reading trackers")
+	}
+
+	var trackers []*Tracker
+	for _, apiTracker := range response.Trackers {
+		tracker := &Tracker{
+			ID:          strconv.Itoa(apiTracker.ID),
+			Name:        apiTracker.Name,
+			Description: apiTracker.Description,
+		}
+		if apiTracker.DefaultStatus != nil {
+			tracker.DefaultStatusID = apiTracker.DefaultStatus.Id
+		}
+		trackers = append(trackers, tracker)
+	}
+
+	return trackers, nil
+}
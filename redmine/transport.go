@@ -0,0 +1,24 @@
+package redmine
+
+import "net/http"
+
+const headerAPIKey = "X-Redmine-API-Key"
+
+// authTransport adds the configured credentials to every request that is sent to Redmine.
+type authTransport struct {
+	config Config
+	next   http.RoundTripper
+}
+
+func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// a RoundTripper must not modify the original request
+	authReq := req.Clone(req.Context())
+
+	if t.config.APIKey != "" {
+		authReq.Header.Set(headerAPIKey, t.config.APIKey)
+	} else {
+		authReq.SetBasicAuth(t.config.Username, t.config.Password)
+	}
+
+	return t.next.RoundTrip(authReq)
+}
@@ -0,0 +1,164 @@
+package redmine
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"net/http"
+	"strconv"
+)
+
+// User statuses as used by the Redmine API.
+const (
+	UserStatusActive     = 1
+	UserStatusRegistered = 2
+	UserStatusLocked     = 3
+)
+
+type User struct {
+	ID               string `json:"id"`
+	Login            string `json:"login"`
+	Firstname        string `json:"firstname"`
+	Lastname         string `json:"lastname"`
+	Mail             string `json:"mail"`
+	Admin            bool   `json:"admin"`
+	Status           int    `json:"status"`
+	AuthSourceID     int    `json:"auth_source_id"`
+	MailNotification string `json:"mail_notification"`
+	MustChangePasswd bool   `json:"must_change_passwd"`
+	// Password is only sent to Redmine if it is not empty. Redmine never returns a user's password.
+	Password    string `json:"password"`
+	CreatedOn   string `json:"created_on"`
+	UpdatedOn   string `json:"updated_on"`
+	LastLoginOn string `json:"last_login_on"`
+}
+
+func (u *User) String() string {
+	return fmt.Sprintf("User{ID=%s,Login=%s,Firstname=%s,Lastname=%s,Mail=%s,Admin=%t,Status=%d}",
+		u.ID, u.Login, u.Firstname, u.Lastname, u.Mail, u.Admin, u.Status)
+}
+
+// apiUser contains the JSON representation of a Redmine user. Not all fields are returned by Redmine when a user is
+// read, f. i. the password or the mail notification setting.
+type apiUser struct {
+	ID               int        `json:"id,omitempty"`
+	Login            string     `json:"login"`
+	Firstname        string     `json:"firstname"`
+	Lastname         string     `json:"lastname"`
+	Mail             string     `json:"mail"`
+	Admin            bool       `json:"admin"`
+	Status           int        `json:"status,omitempty"`
+	AuthSourceID     nullableID `json:"auth_source_id"`
+	MailNotification string     `json:"mail_notification,omitempty"`
+	MustChangePasswd bool       `json:"must_change_passwd"`
+	Password         string     `json:"password,omitempty"`
+	CreatedOn        string     `json:"created_on,omitempty"`
+	UpdatedOn        string     `json:"updated_on,omitempty"`
+	LastLoginOn      string     `json:"last_login_on,omitempty"`
+}
+
+type userEnvelope struct {
+	User apiUser `json:"user"`
+}
+
+func (c *Client) CreateUser(ctx context.Context, user *User) (*User, error) {
+	apiUser := wrapUser(user)
+
+	var response userEnvelope
+	err := c.sendJSON(ctx, http.MethodPost, "/users.json", userEnvelope{User: *apiUser}, &response)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while creating user (login: %s)", user.Login)
+	}
+
+	return unwrapUser(&response.User), nil
+}
+
+func (c *Client) ReadUser(ctx context.Context, id string) (*User, error) {
+	idInt, err := verifyIDtoInt(id)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read user because of malformed input data")
+	}
+
+	var response userEnvelope
+	err = c.getJSON(ctx, fmt.Sprintf("/users/%d.json", idInt), &response)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while reading user (id: %d)", idInt)
+	}
+
+	return unwrapUser(&response.User), nil
+}
+
+func (c *Client) UpdateUser(ctx context.Context, user *User) (*User, error) {
+	idInt, err := verifyIDtoInt(user.ID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not update user (id: %s, login: %s) because of malformed input data",
+			user.ID, user.Login)
+	}
+
+	apiUser := *wrapUser(user)
+
+	err = c.sendJSON(ctx, http.MethodPut, fmt.Sprintf("/users/%d.json", idInt), userEnvelope{User: apiUser}, nil)
+	if err != nil {
+		return user, errors.Wrapf(err, "error while updating user (id: %d, login: %s)", idInt, user.Login)
+	}
+
+	return unwrapUser(&apiUser), nil
+}
+
+func (c *Client) DeleteUser(ctx context.Context, id string) error {
+	idInt, err := verifyIDtoInt(id)
+	if err != nil {
+		return errors.Wrap(err, "could not delete user because of malformed input data")
+	}
+
+	err = c.sendJSON(ctx, http.MethodDelete, fmt.Sprintf("/users/%d.json", idInt), nil, nil)
+	if err != nil {
+		return errors.Wrapf(err, "error while deleting user (id: %d)", idInt)
+	}
+
+	return nil
+}
+
+func wrapUser(user *User) *apiUser {
+	apiUser := &apiUser{
+		Login:            user.Login,
+		Firstname:        user.Firstname,
+		Lastname:         user.Lastname,
+		Mail:             user.Mail,
+		Admin:            user.Admin,
+		Status:           user.Status,
+		AuthSourceID:     nullableID(user.AuthSourceID),
+		MailNotification: user.MailNotification,
+		MustChangePasswd: user.MustChangePasswd,
+		Password:         user.Password,
+	}
+
+	if user.ID != "" {
+		apiUser.ID, _ = strconv.Atoi(user.ID)
+	}
+
+	return apiUser
+}
+
+func unwrapUser(apiUser *apiUser) *User {
+	user := &User{
+		Login:            apiUser.Login,
+		Firstname:        apiUser.Firstname,
+		Lastname:         apiUser.Lastname,
+		Mail:             apiUser.Mail,
+		Admin:            apiUser.Admin,
+		Status:           apiUser.Status,
+		AuthSourceID:     int(apiUser.AuthSourceID),
+		MailNotification: apiUser.MailNotification,
+		MustChangePasswd: apiUser.MustChangePasswd,
+		CreatedOn:        apiUser.CreatedOn,
+		UpdatedOn:        apiUser.UpdatedOn,
+		LastLoginOn:      apiUser.LastLoginOn,
+	}
+
+	if apiUser.ID != 0 {
+		user.ID = strconv.Itoa(apiUser.ID)
+	}
+
+	return user
+}
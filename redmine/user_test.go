@@ -0,0 +1,123 @@
+package redmine
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_CreateUser(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/users.json", r.URL.Path)
+		body, _ := ioutil.ReadAll(r.Body)
+		assert.JSONEq(t, `{"user":{"login":"jdoe","firstname":"Jane","lastname":"Doe","mail":"jdoe@example.com",
+			"admin":false,"status":3,"auth_source_id":"","mail_notification":"none","must_change_passwd":true,"password":"s3cr3t!!"}}`,
+			string(body))
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"user":{"id":8,"login":"jdoe","firstname":"Jane","lastname":"Doe",
+			"mail":"jdoe@example.com","admin":false,"status":3,"created_on":"2021-05-03T10:00:00Z"}}`))
+	}))
+	defer server.Close()
+	sut, err := NewClient(Config{URL: server.URL, APIKey: "secret-key"})
+	require.NoError(t, err)
+
+	user, err := sut.CreateUser(context.Background(), &User{Login: "jdoe", Firstname: "Jane", Lastname: "Doe",
+		Mail: "jdoe@example.com", Status: UserStatusLocked, MailNotification: "none", MustChangePasswd: true,
+		Password: "s3cr3t!!"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "8", user.ID)
+	assert.Equal(t, UserStatusLocked, user.Status)
+	assert.Empty(t, user.Password)
+	assert.Equal(t, "2021-05-03T10:00:00Z", user.CreatedOn)
+}
+
+func TestClient_ReadUser(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/users/8.json", r.URL.Path)
+
+		_, _ = w.Write([]byte(`{"user":{"id":8,"login":"jdoe","firstname":"Jane","lastname":"Doe",
+			"mail":"jdoe@example.com","admin":true,"status":2,"auth_source_id":1,
+			"last_login_on":"2021-05-04T08:00:00Z"}}`))
+	}))
+	defer server.Close()
+	sut, err := NewClient(Config{URL: server.URL, APIKey: "secret-key"})
+	require.NoError(t, err)
+
+	user, err := sut.ReadUser(context.Background(), "8")
+
+	require.NoError(t, err)
+	assert.Equal(t, &User{ID: "8", Login: "jdoe", Firstname: "Jane", Lastname: "Doe", Mail: "jdoe@example.com",
+		Admin: true, Status: UserStatusRegistered, AuthSourceID: 1, LastLoginOn: "2021-05-04T08:00:00Z"}, user)
+}
+
+func TestClient_UpdateUser(t *testing.T) {
+	t.Run("should send changed user without password", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPut, r.Method)
+			assert.Equal(t, "/users/8.json", r.URL.Path)
+			body, _ := ioutil.ReadAll(r.Body)
+			assert.JSONEq(t, `{"user":{"id":8,"login":"jdoe","firstname":"Jane","lastname":"Roe",
+				"mail":"jdoe@example.com","admin":true,"status":1,"auth_source_id":"","must_change_passwd":false}}`,
+				string(body))
+
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+		sut, err := NewClient(Config{URL: server.URL, APIKey: "secret-key"})
+		require.NoError(t, err)
+
+		user, err := sut.UpdateUser(context.Background(), &User{ID: "8", Login: "jdoe", Firstname: "Jane",
+			Lastname: "Roe", Mail: "jdoe@example.com", Admin: true, Status: UserStatusActive})
+
+		require.NoError(t, err)
+		assert.Equal(t, "Roe", user.Lastname)
+	})
+	t.Run("should fail for malformed ID", func(t *testing.T) {
+		sut, err := NewClient(Config{URL: "http://localhost:3000", APIKey: "secret-key"})
+		require.NoError(t, err)
+
+		_, err = sut.UpdateUser(context.Background(), &User{Login: "jdoe"})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "malformed input data")
+	})
+}
+
+func TestClient_DeleteUser(t *testing.T) {
+	t.Run("should delete user", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodDelete, r.Method)
+			assert.Equal(t, "/users/8.json", r.URL.Path)
+
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+		sut, err := NewClient(Config{URL: server.URL, APIKey: "secret-key"})
+		require.NoError(t, err)
+
+		err = sut.DeleteUser(context.Background(), "8")
+
+		require.NoError(t, err)
+	})
+	t.Run("should return not found error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+		sut, err := NewClient(Config{URL: server.URL, APIKey: "secret-key"})
+		require.NoError(t, err)
+
+		err = sut.DeleteUser(context.Background(), "8")
+
+		require.Error(t, err)
+		assert.True(t, IsNotFound(err))
+	})
+}
@@ -5,53 +5,95 @@ import (
 	"fmt"
 	rmapi "github.com/cloudogu/go-redmine"
 	"github.com/pkg/errors"
+	"net/http"
 	"strconv"
 )
 
+// VersionSharingModes contains the values that Redmine accepts for the sharing of a version with other projects.
+var VersionSharingModes = []string{"none", "descendants", "hierarchy", "tree", "system"}
+
 type Version struct {
 	ID          string `json:"id"`
 	ProjectID   int    `json:"project_id"`
 	Name        string `json:"name"`
 	Description string `json:"description"`
 	Status      string `json:"status"`
-	DueDate     string `json:"due_date"`
-	CreatedOn   string `json:"created_on"`
-	UpdatedOn   string `json:"updated_on"`
+	// DueDate contains the date of the version in the format YYYY-MM-DD. Redmine stores it as effective_date but
+	// returns it as due_date. An empty due date removes the date.
+	DueDate string `json:"due_date"`
+	// Sharing contains one of the VersionSharingModes. Redmine uses "none" if it is empty.
+	Sharing       string `json:"sharing"`
+	WikiPageTitle string `json:"wiki_page_title"`
+	// EstimatedHours and SpentHours contain the sums of the version's issues. They are only filled when reading
+	// versions.
+	EstimatedHours float64 `json:"estimated_hours"`
+	SpentHours     float64 `json:"spent_hours"`
+	CreatedOn      string  `json:"created_on"`
+	UpdatedOn      string  `json:"updated_on"`
+	// CustomFields contains the values of the version's custom fields. Custom fields that are not contained are left
+	// unchanged.
+	CustomFields []CustomField `json:"custom_fields"`
+}
+
+// apiVersion contains the JSON representation of a Redmine version. Redmine expects the date as effective_date in
+// requests but returns it as due_date in responses.
+type apiVersion struct {
+	ID             int                  `json:"id,omitempty"`
+	Project        *rmapi.IdName        `json:"project,omitempty"`
+	Name           string               `json:"name"`
+	Description    string               `json:"description"`
+	Status         string               `json:"status,omitempty"`
+	EffectiveDate  *string              `json:"effective_date,omitempty"`
+	DueDate        string               `json:"due_date,omitempty"`
+	Sharing        string               `json:"sharing,omitempty"`
+	WikiPageTitle  string               `json:"wiki_page_title"`
+	EstimatedHours float64              `json:"estimated_hours,omitempty"`
+	SpentHours     float64              `json:"spent_hours,omitempty"`
+	CreatedOn      string               `json:"created_on,omitempty"`
+	UpdatedOn      string               `json:"updated_on,omitempty"`
+	CustomFields   []*rmapi.CustomField `json:"custom_fields,omitempty"`
+}
+
+type versionEnvelope struct {
+	Version apiVersion `json:"version"`
 }
 
 func (i *Version) String() string {
 	return fmt.Sprintf("Version{ID=%s,ProjectID=%d,Name=%s}", i.ID, i.ProjectID, i.Name)
 }
 
-func (c *Client) CreateVersion(_ context.Context, Version *Version) (*Version, error) {
+func (c *Client) CreateVersion(ctx context.Context, Version *Version) (*Version, error) {
 	apiVersion := wrapVersion(Version)
 
-	createdAPIVersion, err := c.redmineAPI.CreateVersion(*apiVersion)
+	var response versionEnvelope
+	path := fmt.Sprintf("/projects/%d/versions.json", Version.ProjectID)
+	err := c.sendJSON(ctx, http.MethodPost, path, versionEnvelope{Version: *apiVersion}, &response)
 	if err != nil {
 		return nil, errors.Wrapf(err, "error while creating version (project id: %d, name: %s)", Version.ProjectID, Version.Name)
 	}
 
-	actualVersion := unwrapVersion(createdAPIVersion)
+	actualVersion := unwrapVersion(&response.Version)
 
 	return actualVersion, nil
 }
 
-func (c *Client) ReadVersion(_ context.Context, id string) (Version *Version, err error) {
+func (c *Client) ReadVersion(ctx context.Context, id string) (Version *Version, err error) {
 	idInt, err := verifyIDtoInt(id)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not read version because of malformed input data")
 	}
 
-	apiVersion, err := c.redmineAPI.Version(idInt)
+	var response versionEnvelope
+	err = c.getJSON(ctx, fmt.Sprintf("/versions/%d.json", idInt), &response)
 	if err != nil {
 		return Version, errors.Wrapf(err, "error while reading version (id: %d)", idInt)
 	}
 
-	return unwrapVersion(apiVersion), nil
+	return unwrapVersion(&response.Version), nil
 }
 
-func (c *Client) UpdateVersion(_ context.Context, Version *Version) (updatedVersion *Version, err error) {
-	_, err = verifyIDtoInt(Version.ID)
+func (c *Client) UpdateVersion(ctx context.Context, Version *Version) (updatedVersion *Version, err error) {
+	idInt, err := verifyIDtoInt(Version.ID)
 	if err != nil {
 		return nil, errors.Wrapf(err, "could not update version (id: %s, name: %s) because of malformed input data",
 			Version.ID, Version.Name)
@@ -59,21 +101,21 @@ func (c *Client) UpdateVersion(_ context.Context, Version *Version) (updatedVers
 
 	apiVersion := *wrapVersion(Version)
 
-	err = c.redmineAPI.UpdateVersion(apiVersion)
+	err = c.sendJSON(ctx, http.MethodPut, fmt.Sprintf("/versions/%d.json", idInt), versionEnvelope{Version: apiVersion}, nil)
 	if err != nil {
-		return Version, errors.Wrapf(err, "error while updating version (id: %d, name: %s)", apiVersion.Id, Version.Name)
+		return Version, errors.Wrapf(err, "error while updating version (id: %d, name: %s)", idInt, Version.Name)
 	}
 
-	return unwrapVersion(&apiVersion), nil
+	return Version, nil
 }
 
-func (c *Client) DeleteVersion(_ context.Context, id string) error {
+func (c *Client) DeleteVersion(ctx context.Context, id string) error {
 	idInt, err := verifyIDtoInt(id)
 	if err != nil {
 		return errors.Wrap(err, "could not delete version because of malformed input data")
 	}
 
-	err = c.redmineAPI.DeleteVersion(idInt)
+	err = c.sendJSON(ctx, http.MethodDelete, fmt.Sprintf("/versions/%d.json", idInt), nil, nil)
 	if err != nil {
 		return errors.Wrapf(err, "error while deleteting version (id: %d)", idInt)
 	}
@@ -81,35 +123,40 @@ func (c *Client) DeleteVersion(_ context.Context, id string) error {
 	return nil
 }
 
-func wrapVersion(Version *Version) *rmapi.Version {
-	apiVersion := &rmapi.Version{
-		Project:     rmapi.IdName{Id: Version.ProjectID},
-		Name:        Version.Name,
-		Description: Version.Description,
-		Status:      Version.Status,
-		DueDate:     Version.DueDate,
-		CreatedOn:   Version.CreatedOn,
-		UpdatedOn:   Version.UpdatedOn,
-	}
-
-	if Version.ID != "" {
-		apiVersion.Id, _ = strconv.Atoi(Version.ID)
+func wrapVersion(version *Version) *apiVersion {
+	effectiveDate := version.DueDate
+	apiVersion := &apiVersion{
+		Name:          version.Name,
+		Description:   version.Description,
+		Status:        version.Status,
+		EffectiveDate: &effectiveDate,
+		Sharing:       version.Sharing,
+		WikiPageTitle: version.WikiPageTitle,
+		CustomFields:  wrapCustomFields(version.CustomFields),
 	}
 
 	return apiVersion
 }
 
-func unwrapVersion(apiVersion *rmapi.Version) *Version {
-	Version := &Version{
-		ID:          strconv.Itoa(apiVersion.Id),
-		ProjectID:   apiVersion.Project.Id,
-		Name:        apiVersion.Name,
-		Description: apiVersion.Description,
-		Status:      apiVersion.Status,
-		DueDate:     apiVersion.DueDate,
-		CreatedOn:   apiVersion.CreatedOn,
-		UpdatedOn:   apiVersion.UpdatedOn,
+func unwrapVersion(apiVersion *apiVersion) *Version {
+	version := &Version{
+		ID:             strconv.Itoa(apiVersion.ID),
+		Name:           apiVersion.Name,
+		Description:    apiVersion.Description,
+		Status:         apiVersion.Status,
+		DueDate:        apiVersion.DueDate,
+		Sharing:        apiVersion.Sharing,
+		WikiPageTitle:  apiVersion.WikiPageTitle,
+		EstimatedHours: apiVersion.EstimatedHours,
+		SpentHours:     apiVersion.SpentHours,
+		CreatedOn:      apiVersion.CreatedOn,
+		UpdatedOn:      apiVersion.UpdatedOn,
+		CustomFields:   unwrapCustomFields(apiVersion.CustomFields),
+	}
+
+	if apiVersion.Project != nil {
+		version.ProjectID = apiVersion.Project.Id
 	}
 
-	return Version
+	return version
 }
@@ -0,0 +1,71 @@
+package redmine
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_UpdateVersion(t *testing.T) {
+	t.Run("should send the due date as effective date", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPut, r.Method)
+			assert.Equal(t, "/versions/4.json", r.URL.Path)
+			body, _ := ioutil.ReadAll(r.Body)
+			assert.JSONEq(t, `{"version":{"name":"1.0","description":"first release","status":"open",
+				"effective_date":"2021-06-30","sharing":"descendants","wiki_page_title":"Release_notes"}}`, string(body))
+
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+		sut, err := NewClient(Config{URL: server.URL, APIKey: "secret-key"})
+		require.NoError(t, err)
+
+		_, err = sut.UpdateVersion(context.Background(), &Version{ID: "4", ProjectID: 1, Name: "1.0",
+			Description: "first release", Status: "open", DueDate: "2021-06-30", Sharing: "descendants",
+			WikiPageTitle: "Release_notes"})
+
+		require.NoError(t, err)
+	})
+	t.Run("should send an empty effective date to remove the date", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := ioutil.ReadAll(r.Body)
+			assert.Contains(t, string(body), `"effective_date":""`)
+
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+		sut, err := NewClient(Config{URL: server.URL, APIKey: "secret-key"})
+		require.NoError(t, err)
+
+		_, err = sut.UpdateVersion(context.Background(), &Version{ID: "4", ProjectID: 1, Name: "1.0"})
+
+		require.NoError(t, err)
+	})
+}
+
+func TestClient_ReadVersion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/versions/4.json", r.URL.Path)
+		_, _ = w.Write([]byte(`{"version":{"id":4,"project":{"id":1,"name":"Platform"},"name":"1.0",
+			"description":"first release","status":"open","due_date":"2021-06-30","sharing":"tree",
+			"wiki_page_title":"Release_notes","estimated_hours":12.5,"spent_hours":3.25,
+			"created_on":"2021-06-01T10:00:00Z","updated_on":"2021-06-02T10:00:00Z"}}`))
+	}))
+	defer server.Close()
+	sut, err := NewClient(Config{URL: server.URL, APIKey: "secret-key"})
+	require.NoError(t, err)
+
+	version, err := sut.ReadVersion(context.Background(), "4")
+
+	require.NoError(t, err)
+	expected := &Version{ID: "4", ProjectID: 1, Name: "1.0", Description: "first release", Status: "open",
+		DueDate: "2021-06-30", Sharing: "tree", WikiPageTitle: "Release_notes", EstimatedHours: 12.5, SpentHours: 3.25,
+		CreatedOn: "2021-06-01T10:00:00Z", UpdatedOn: "2021-06-02T10:00:00Z"}
+	assert.Equal(t, expected, version)
+}
@@ -0,0 +1,177 @@
+package redmine
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// WikiPage contains a page of a project's wiki. Wiki pages are identified by their project and their title.
+type WikiPage struct {
+	ProjectID int    `json:"project_id"`
+	Title     string `json:"title"`
+	// ParentTitle contains the title of the parent page or is empty for top-level pages.
+	ParentTitle string `json:"parent_title"`
+	Text        string `json:"text"`
+	// Comments describes the change of the text. Redmine only stores it if the text changes.
+	Comments string `json:"comments"`
+	// Version contains the number of the page's current version. Updates fail with a ConflictError if the page was
+	// changed after this version.
+	Version   int    `json:"version"`
+	CreatedOn string `json:"created_on"`
+	UpdatedOn string `json:"updated_on"`
+	// Uploads contains uploaded files which are attached to the page when it is created or updated.
+	Uploads []Upload `json:"uploads"`
+	// Attachments contains the files that are attached to the page. It is only filled when reading pages.
+	Attachments []Attachment `json:"attachments"`
+}
+
+func (w *WikiPage) String() string {
+	return fmt.Sprintf("WikiPage{ProjectID=%d,Title=%s,ParentTitle=%s,Version=%d}", w.ProjectID, w.Title,
+		w.ParentTitle, w.Version)
+}
+
+var wikiTitleWhitespace = regexp.MustCompile(`\s+`)
+
+// WikiTitle returns the title that Redmine stores for the given title: whitespace is replaced by underscores, the
+// characters ,./?;|: are removed and the first letter is upper-cased, f. i. "Start page" becomes "Start_page".
+func WikiTitle(title string) string {
+	title = wikiTitleWhitespace.ReplaceAllString(title, "_")
+	title = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(",./?;|:", r) {
+			return -1
+		}
+		return r
+	}, title)
+
+	first, size := utf8.DecodeRuneInString(title)
+	if size == 0 {
+		return title
+	}
+
+	return string(unicode.ToUpper(first)) + title[size:]
+}
+
+// apiWikiPage contains the JSON representation of a wiki page. Redmine expects the parent as parent_title in requests
+// but returns it as parent in responses. An empty parent_title removes the parent.
+type apiWikiPage struct {
+	Title       string             `json:"title,omitempty"`
+	ParentTitle string             `json:"parent_title"`
+	Parent      *apiWikiPageParent `json:"parent,omitempty"`
+	Text        string             `json:"text"`
+	Comments    string             `json:"comments,omitempty"`
+	Version     int                `json:"version,omitempty"`
+	CreatedOn   string             `json:"created_on,omitempty"`
+	UpdatedOn   string             `json:"updated_on,omitempty"`
+	Uploads     []apiUpload        `json:"uploads,omitempty"`
+	Attachments []Attachment       `json:"attachments,omitempty"`
+}
+
+type apiWikiPageParent struct {
+	Title string `json:"title"`
+}
+
+type wikiPageEnvelope struct {
+	WikiPage apiWikiPage `json:"wiki_page"`
+}
+
+// CreateWikiPage creates a wiki page. Redmine creates and updates wiki pages with the same request, so the page is
+// read first to avoid overwriting an existing page.
+func (c *Client) CreateWikiPage(ctx context.Context, page *WikiPage) (*WikiPage, error) {
+	_, err := c.ReadWikiPage(ctx, page.ProjectID, page.Title)
+	if err == nil {
+		return nil, errors.Errorf("error while creating wiki page (project id: %d, title: %s): the page already exists",
+			page.ProjectID, page.Title)
+	}
+	if !IsNotFound(err) {
+		return nil, errors.Wrapf(err, "error while creating wiki page (project id: %d, title: %s)", page.ProjectID, page.Title)
+	}
+
+	apiPage := wrapWikiPage(page)
+	apiPage.Version = 0
+
+	err = c.sendJSON(ctx, http.MethodPut, wikiPagePath(page.ProjectID, page.Title), wikiPageEnvelope{WikiPage: *apiPage}, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while creating wiki page (project id: %d, title: %s)", page.ProjectID, page.Title)
+	}
+
+	return c.ReadWikiPage(ctx, page.ProjectID, page.Title)
+}
+
+func (c *Client) ReadWikiPage(ctx context.Context, projectID int, title string) (*WikiPage, error) {
+	if projectID <= 0 || title == "" {
+		return nil, errors.Errorf("could not read wiki page because of malformed input data: project id %d and title '%s' must be set",
+			projectID, title)
+	}
+
+	var response wikiPageEnvelope
+	err := c.getJSON(ctx, wikiPagePath(projectID, title)+"?include=attachments", &response)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while reading wiki page (project id: %d, title: %s)", projectID, title)
+	}
+
+	page := unwrapWikiPage(&response.WikiPage)
+	page.ProjectID = projectID
+
+	return page, nil
+}
+
+// UpdateWikiPage updates the text and the parent of an existing wiki page. A ConflictError is returned if the page
+// was changed after the page's version.
+func (c *Client) UpdateWikiPage(ctx context.Context, page *WikiPage) (*WikiPage, error) {
+	apiPage := wrapWikiPage(page)
+
+	err := c.sendJSON(ctx, http.MethodPut, wikiPagePath(page.ProjectID, page.Title), wikiPageEnvelope{WikiPage: *apiPage}, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while updating wiki page (project id: %d, title: %s)", page.ProjectID, page.Title)
+	}
+
+	return page, nil
+}
+
+func (c *Client) DeleteWikiPage(ctx context.Context, projectID int, title string) error {
+	err := c.sendJSON(ctx, http.MethodDelete, wikiPagePath(projectID, title), nil, nil)
+	if err != nil {
+		return errors.Wrapf(err, "error while deleting wiki page (project id: %d, title: %s)", projectID, title)
+	}
+
+	return nil
+}
+
+func wikiPagePath(projectID int, title string) string {
+	return fmt.Sprintf("/projects/%d/wiki/%s.json", projectID, url.PathEscape(WikiTitle(title)))
+}
+
+func wrapWikiPage(page *WikiPage) *apiWikiPage {
+	return &apiWikiPage{
+		ParentTitle: page.ParentTitle,
+		Text:        page.Text,
+		Comments:    page.Comments,
+		Version:     page.Version,
+		Uploads:     wrapUploads(page.Uploads),
+	}
+}
+
+func unwrapWikiPage(apiPage *apiWikiPage) *WikiPage {
+	page := &WikiPage{
+		Title:       apiPage.Title,
+		Text:        apiPage.Text,
+		Comments:    apiPage.Comments,
+		Version:     apiPage.Version,
+		CreatedOn:   apiPage.CreatedOn,
+		UpdatedOn:   apiPage.UpdatedOn,
+		Attachments: apiPage.Attachments,
+	}
+
+	if apiPage.Parent != nil {
+		page.ParentTitle = apiPage.Parent.Title
+	}
+
+	return page
+}
@@ -0,0 +1,77 @@
+package redmine
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWikiTitle(t *testing.T) {
+	assert.Equal(t, "Start_page", WikiTitle("start  page"))
+	assert.Equal(t, "Runbook_v2", WikiTitle("Runbook: v.2"))
+	assert.Equal(t, "Äpfel", WikiTitle("äpfel"))
+	assert.Equal(t, "", WikiTitle(""))
+}
+
+func TestClient_ReadWikiPage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/projects/2/wiki/Start_page.json", r.URL.Path)
+		_, _ = w.Write([]byte(`{"wiki_page":{"title":"Start_page","parent":{"title":"Wiki"},"text":"h1. Start\r\n",
+			"version":3,"comments":"initial","created_on":"2021-06-01T10:00:00Z","updated_on":"2021-06-02T10:00:00Z"}}`))
+	}))
+	defer server.Close()
+	sut, err := NewClient(Config{URL: server.URL, APIKey: "secret-key"})
+	require.NoError(t, err)
+
+	page, err := sut.ReadWikiPage(context.Background(), 2, "Start page")
+
+	require.NoError(t, err)
+	expected := &WikiPage{ProjectID: 2, Title: "Start_page", ParentTitle: "Wiki", Text: "h1. Start\r\n", Version: 3,
+		Comments: "initial", CreatedOn: "2021-06-01T10:00:00Z", UpdatedOn: "2021-06-02T10:00:00Z"}
+	assert.Equal(t, expected, page)
+}
+
+func TestClient_UpdateWikiPage(t *testing.T) {
+	t.Run("should send the version to detect concurrent changes", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPut, r.Method)
+			assert.Equal(t, "/projects/2/wiki/Runbook.json", r.URL.Path)
+			body, _ := ioutil.ReadAll(r.Body)
+			assert.JSONEq(t, `{"wiki_page":{"parent_title":"","text":"new text","comments":"update","version":3}}`, string(body))
+
+			w.WriteHeader(http.StatusConflict)
+		}))
+		defer server.Close()
+		sut, err := NewClient(Config{URL: server.URL, APIKey: "secret-key"})
+		require.NoError(t, err)
+
+		_, err = sut.UpdateWikiPage(context.Background(), &WikiPage{ProjectID: 2, Title: "Runbook", Text: "new text",
+			Comments: "update", Version: 3})
+
+		require.Error(t, err)
+		var conflictErr *ConflictError
+		assert.ErrorAs(t, err, &conflictErr)
+	})
+}
+
+func TestClient_CreateWikiPage(t *testing.T) {
+	t.Run("should not overwrite an existing page", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method)
+			_, _ = w.Write([]byte(`{"wiki_page":{"title":"Runbook","text":"existing","version":1}}`))
+		}))
+		defer server.Close()
+		sut, err := NewClient(Config{URL: server.URL, APIKey: "secret-key"})
+		require.NoError(t, err)
+
+		_, err = sut.CreateWikiPage(context.Background(), &WikiPage{ProjectID: 2, Title: "Runbook", Text: "new text"})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "the page already exists")
+	})
+}